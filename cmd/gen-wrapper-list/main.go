@@ -0,0 +1,156 @@
+// cmd/gen-wrapper-list/main.go
+// pkg/analyzer/data/glibc_wrappers.txt (번들 래퍼 목록) 생성기
+//
+//	go run ./cmd/gen-wrapper-list -libc ./libc.so.6 [-syscalls-list <glibc>/sysdeps/unix/sysv/linux/syscalls.list ...] > pkg/analyzer/data/glibc_wrappers.txt
+//
+// 후보 = 커널 시스템 콜 이름 (pkg/syscalls) + glibc syscalls.list 의 strong/weak 이름 + 이름이 다른 glibc 래퍼(glibcAliases)
+// -libc 를 주면 그 libc가 실제로 export 하는 FUNC 심볼만 남김
+package main
+
+import (
+	"bufio"
+	"debug/elf"
+	"flag"
+	"fmt"
+	"ips_bpf/static-analyzer/pkg/syscalls"
+	"log"
+	"os"
+	"sort"
+	"strings"
+)
+
+// glibcAliases : 커널 시스템 콜과 이름이 다르지만 시스템 콜을 직접 호출하는 glibc 래퍼
+// (LFS 64 접미사, 구 인터페이스, 다른 시스템 콜로 구현된 래퍼 등)
+var glibcAliases = []string{
+	// LFS (*64) 별칭
+	"open64", "openat64", "creat64", "lseek64", "pread", "pwrite", "preadv", "pwritev", "preadv2", "pwritev2",
+	"preadv64", "pwritev64", "preadv64v2", "pwritev64v2", "mmap64", "truncate64", "ftruncate64",
+	"stat64", "fstat64", "lstat64", "fstatat", "fstatat64", "statfs64", "fstatfs64", "statvfs", "fstatvfs",
+	"statvfs64", "fstatvfs64", "getdents64", "fallocate64", "posix_fallocate", "posix_fallocate64",
+	"posix_fadvise", "posix_fadvise64", "sendfile64", "getrlimit64", "setrlimit64", "prlimit", "lockf", "lockf64",
+	"fcntl64", "glob64",
+	// 다른 시스템 콜로 구현된 래퍼
+	"wait", "waitpid", "waitid", "wait3", "sbrk", "sigaction", "sigprocmask", "sigsuspend", "sigpending",
+	"sigtimedwait", "sigwaitinfo", "sigwait", "sigqueue", "signal", "raise", "abort", "umount", "sleep", "usleep",
+	"clock_nanosleep", "nice", "getpgrp", "setpgrp", "dup3", "pipe2", "accept4", "recvmmsg", "sendmmsg",
+	"epoll_pwait2", "posix_openpt", "getpt", "ptsname", "tcgetattr", "tcsetattr", "isatty", "ttyname",
+	"getentropy", "getrandom", "gettid", "tgkill", "tkill", "pidfd_open", "pidfd_send_signal", "pidfd_getfd",
+	"close_range", "closefrom", "execv", "execvp", "execvpe", "execl", "execlp", "execle", "fexecve", "execveat",
+	"posix_spawn", "posix_spawnp", "_exit", "_Exit", "mkfifo", "mkfifoat", "mknodat", "utime", "utimes",
+	"futimes", "lutimes", "futimens", "utimensat", "getpagesize", "getloadavg", "gethostname", "sethostname",
+	"getdomainname", "setdomainname", "ulimit", "vhangup", "syscall",
+}
+
+func main() {
+	libcPath := flag.String("libc", "", "export 심볼로 후보를 거를 libc.so.6 경로 (없으면 거르지 않음)")
+	var lists multiFlag
+	flag.Var(&lists, "syscalls-list", "glibc syscalls.list 파일 (여러 번 지정 가능)")
+	flag.Parse()
+
+	candidates := make(map[string]struct{})
+	for _, name := range syscalls.KernelSyscallNames() {
+		candidates[name] = struct{}{}
+	}
+	for _, name := range glibcAliases {
+		candidates[name] = struct{}{}
+	}
+	for _, path := range lists {
+		names, err := readSyscallsList(path)
+		if err != nil {
+			log.Fatalf("%s: %v", path, err)
+		}
+		for _, name := range names {
+			candidates[name] = struct{}{}
+		}
+	}
+
+	if *libcPath != "" {
+		exports, err := exportedFuncs(*libcPath)
+		if err != nil {
+			log.Fatalf("%s: %v", *libcPath, err)
+		}
+		for name := range candidates {
+			if _, ok := exports[name]; !ok {
+				delete(candidates, name)
+			}
+		}
+	}
+
+	names := make([]string, 0, len(candidates))
+	for name := range candidates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	w := bufio.NewWriter(os.Stdout)
+	defer w.Flush()
+	fmt.Fprintln(w, "# glibc가 export 하는 x86_64 시스템 콜 래퍼 목록 (analyzer.SourceBundled)")
+	fmt.Fprintln(w, "# 이 파일은 cmd/gen-wrapper-list 로 생성됩니다. 직접 수정하지 말고 생성기의 glibcAliases 또는 입력을 고치세요.")
+	if *libcPath != "" {
+		fmt.Fprintf(w, "# libc: %s\n", *libcPath)
+	}
+	for _, name := range names {
+		fmt.Fprintln(w, name)
+	}
+}
+
+// exportedFuncs : libc가 정의(export)하는 FUNC 심볼 이름
+func exportedFuncs(path string) (map[string]struct{}, error) {
+	f, err := elf.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	symbols, err := f.DynamicSymbols()
+	if err != nil {
+		return nil, err
+	}
+	exports := make(map[string]struct{})
+	for _, sym := range symbols {
+		if sym.Section == elf.SHN_UNDEF || elf.ST_TYPE(sym.Info) != elf.STT_FUNC {
+			continue
+		}
+		exports[sym.Name] = struct{}{}
+	}
+	return exports, nil
+}
+
+// readSyscallsList : glibc syscalls.list 에서 strong/weak 이름 추출 ("__" 로 시작하는 내부 이름 제외)
+// 형식: # File name	Caller	Syscall name	Args	Strong name	Weak names
+func readSyscallsList(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var names []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 5 {
+			continue
+		}
+		aliases := []string{fields[4]}
+		if len(fields) > 5 {
+			aliases = append(aliases, strings.Split(fields[5], ",")...)
+		}
+		for _, name := range aliases {
+			if name != "" && name != "-" && !strings.HasPrefix(name, "__") {
+				names = append(names, name)
+			}
+		}
+	}
+	return names, scanner.Err()
+}
+
+// multiFlag : 여러 번 지정할 수 있는 문자열 플래그
+type multiFlag []string
+
+func (m *multiFlag) String() string     { return strings.Join(*m, ",") }
+func (m *multiFlag) Set(v string) error { *m = append(*m, v); return nil }
@@ -0,0 +1,26 @@
+// cmd/static-analyzer/banner.go
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+// printBanner : 시작 배너 출력 (--banner), JSON 출력이 섞이지 않도록 표준 에러로 출력
+func printBanner(w io.Writer) {
+	//https://patorjk.com/software/taag/
+	//Big 글꼴 사용
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, `                  %%@@@@@@@@%       %@@@@@@@@@&    `)
+	fmt.Fprintln(w, `                @@@@@@@@@@@@@@@  %@@@@@@@@@@@@@@@    ______ _      ______    _____ _        _   _                             _                    `)
+	fmt.Fprintln(w, `    @@@@@@@@@ %@@@@@@%@%@@@@@@@@@@@@@@%@%%@@@@@@@@  |  ____| |    |  ____|  / ____| |      | | (_)          /\               | |                   `)
+	fmt.Fprintln(w, `@@@@@@@@@@@@%%@@@@@%         %@@@@@@@       %@@@@@  | |__  | |    | |__    | (___ | |_ __ _| |_ _  ___     /  \   _ __   __ _| |_   _ _______ _ __ `)
+	fmt.Fprintln(w, `      @@@@@% %@@@@@   @@@@@@@@@@@@@@         @@@@@  |  __| | |    |  __|    \___ \| __/ _' | __| |/ __|   / /\ \ | '_ \ / _' | | | | |_  / _ \ '__|`)
+	fmt.Fprintln(w, `             %@@@@@   %%%%@@@@@@@@@@%       @@@@@@  | |____| |____| |       ____) | || (_| | |_| | (__   / ____ \| | | | (_| | | |_| |/ /  __/ |   `)
+	fmt.Fprintln(w, `             %@@@@@@%%%%%@@@@@%@@@@@@%%  @@@@@@@@   |______|______|_|      |_____/ \__\__,_|\__|_|\___| /_/    \_\_| |_|\__,_|_|\__, /___\___|_|   `)
+	fmt.Fprintln(w, `              @@@@@@@@@@@@@@@@  @@@@@@@@@@@@@%@@                                                                                 __/ |             `)
+	fmt.Fprintln(w, `                %@@@@@@@@%%       %@@@@@@@@@@%                                                                                  |___/              `)
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, " [ ELF Static Analyzer Booting... ]")
+	fmt.Fprintln(w)
+}
@@ -0,0 +1,105 @@
+// cmd/static-analyzer/commands.go
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"ips_bpf/static-analyzer/pkg/analyzer"
+	"ips_bpf/static-analyzer/pkg/storage"
+	"log"
+	"os"
+)
+
+// subcommands : 분석 외의 작업 (static-analyzer <명령> [옵션] <인자>)
+// 옵션은 분석과 같은 전역 플래그(--store 등)를 사용
+var subcommands = map[string]func(ctx context.Context, args []string) error{
+	"delete":    runDelete,
+	"recompute": runRecompute,
+	"prewarm":   runPrewarm,
+}
+
+// runSubcommand : os.Args[1]이 하위 명령이면 실행하고 true 반환
+func runSubcommand() bool {
+	if len(os.Args) < 2 {
+		return false
+	}
+	cmd, ok := subcommands[os.Args[1]]
+	if !ok {
+		return false
+	}
+
+	flag.CommandLine.Parse(os.Args[2:])
+	applyStoreFlags()
+	ctx, stop := newContext()
+	err := cmd(ctx, flag.Args())
+	stop()
+	if err != nil {
+		log.Fatalf("%s 실패: %v", os.Args[1], err)
+	}
+	return true
+}
+
+// runDelete : 바이너리 하나의 저장 결과를 지우고 클러스터 Set을 갱신
+// 인자는 sha256 또는 ELF 파일 경로 (파일이면 sha256을 계산)
+func runDelete(ctx context.Context, args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("사용법: static-analyzer delete [옵션] <sha256 | ELF 파일 경로>...")
+	}
+
+	store, err := openStore(ctx)
+	if err != nil {
+		return fmt.Errorf("결과 저장소 연결 실패: %w", err)
+	}
+	defer store.Close()
+
+	for _, arg := range args {
+		id, err := resolveBinaryID(arg)
+		if err != nil {
+			return err
+		}
+		err = store.Delete(ctx, id)
+		if errors.Is(err, storage.ErrNotFound) {
+			log.Printf("  [정보] %s: 저장된 결과 없음\n", arg)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("%s 삭제 실패: %w", arg, err)
+		}
+		log.Printf("  [성공] %s (%s) 결과 삭제 완료\n", arg, id)
+	}
+	return nil
+}
+
+// runRecompute : cluster_callable_syscalls 를 바이너리별 Set으로부터 다시 계산 (redis 저장소 전용)
+func runRecompute(ctx context.Context, _ []string) error {
+	store, err := openStore(ctx)
+	if err != nil {
+		return fmt.Errorf("결과 저장소 연결 실패: %w", err)
+	}
+	defer store.Close()
+
+	rs, ok := store.(*storage.RedisStore)
+	if !ok {
+		return fmt.Errorf("recompute 는 redis 저장소에서만 지원합니다 (현재: %s)", *storeFlag)
+	}
+	if err := rs.RecomputeClusterSet(ctx); err != nil {
+		return err
+	}
+	log.Printf("  [성공] %s 재계산 완료\n", storage.ClusterSyscallSetKey)
+	return nil
+}
+
+// resolveBinaryID : 인자가 존재하는 파일이면 sha256을 계산하고, 아니면 sha256으로 간주
+func resolveBinaryID(arg string) (string, error) {
+	if _, err := os.Stat(arg); err != nil {
+		return arg, nil
+	}
+	a, err := analyzer.New(arg)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", arg, err)
+	}
+	defer a.Close()
+	return a.SHA256()
+}
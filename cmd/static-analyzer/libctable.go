@@ -0,0 +1,151 @@
+// cmd/static-analyzer/libctable.go
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"ips_bpf/static-analyzer/pkg/analyzer"
+	"ips_bpf/static-analyzer/pkg/config"
+	"ips_bpf/static-analyzer/pkg/processor"
+	"ips_bpf/static-analyzer/pkg/storage"
+	"log"
+	"os"
+	"time"
+)
+
+// loadLibcTable : 저장소에서 libc 심볼별 시스템 콜 표를 읽음
+// 표가 없거나, 분석기 버전이 다르거나, 저장소를 쓸 수 없거나, --force 이면 빈 표를 반환 (항상 nil이 아님)
+func loadLibcTable(ctx context.Context, meta storage.Meta) *storage.LibcTable {
+	fresh := storage.NewLibcTable(meta.LibcPath, meta.LibcBuildID, meta.LibcSHA256, config.AnalyzerVersion)
+	if *forceFlag || *storeFlag == storage.KindNone {
+		return fresh
+	}
+
+	store, err := openStoreWithRetry(ctx, storage.RetryPolicy{Attempts: 1})
+	if err != nil {
+		log.Printf("[경고] libc 표 조회를 위한 저장소 연결 실패, libc를 직접 추적합니다: %v\n", err)
+		return fresh
+	}
+	defer store.Close()
+
+	t, err := store.LoadLibcTable(ctx, fresh.Key)
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		return fresh
+	case err != nil:
+		log.Printf("[경고] libc 표 조회 실패, libc를 직접 추적합니다: %v\n", err)
+		return fresh
+	case t.AnalyzerVersion != config.AnalyzerVersion:
+		fmt.Fprintf(os.Stderr, "libc 표 무효 (분석기 버전 %s != %s): libc를 다시 추적합니다.\n", t.AnalyzerVersion, config.AnalyzerVersion)
+		return fresh
+	}
+	fmt.Fprintf(os.Stderr, "libc 표 사용: %s (심볼 %d개 추적 완료)\n", t.Key, len(t.Syscalls))
+	return t
+}
+
+// libcSyscallFunctions : --catalog=libc 래퍼 목록 (libc의 export 함수 중 syscall 명령어가 있는 것)
+// 표에 캐시된 목록이 있으면 그대로 쓰고, 없으면 계산하여 표에 기록 (실패하면 nil, 호출자는 bundled 목록으로 대체)
+func libcSyscallFunctions(ctx context.Context, libc *analyzer.ELFAnalyzer, t *storage.LibcTable) []string {
+	if t.Wrappers != nil {
+		fmt.Fprintf(os.Stderr, "libc 래퍼 목록 사용: %s (%d개)\n", t.Key, len(t.Wrappers))
+		return t.Wrappers
+	}
+
+	fmt.Fprintln(os.Stderr, "libc export 함수에서 syscall 명령어를 찾는 중...")
+	start := time.Now()
+	names, err := libc.SyscallFunctions(ctx, *concurrencyFlag)
+	if err != nil {
+		log.Printf("[경고] libc 래퍼 목록 계산 실패: %v\n", err)
+		return nil
+	}
+	t.Wrappers = names
+	fmt.Fprintf(os.Stderr, "libc 래퍼 목록 계산 완료: %d개 (%s)\n", len(names), time.Since(start))
+	return names
+}
+
+// runPrewarm : libc 파일마다 시스템 콜 래퍼로 보이는 모든 export 심볼을 추적하여 libc 표를 미리 저장
+// 이후 해당 libc에 링크된 바이너리는 역어셈 없이 표만 조회함
+func runPrewarm(ctx context.Context, args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("사용법: static-analyzer prewarm [옵션] <libc 경로>...")
+	}
+	if *storeFlag == storage.KindNone {
+		return fmt.Errorf("prewarm 은 결과 저장소가 필요합니다 (--store=redis 또는 file)")
+	}
+
+	store, err := openStore(ctx)
+	if err != nil {
+		return fmt.Errorf("결과 저장소 연결 실패: %w", err)
+	}
+	defer store.Close()
+
+	for _, path := range args {
+		if err := prewarmLibc(ctx, store, path); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// prewarmLibc : libc 하나의 표를 채워 저장 (이미 추적한 심볼은 건너뜀, --force 이면 처음부터)
+func prewarmLibc(ctx context.Context, store storage.ResultStore, path string) error {
+	libc, err := analyzer.New(path)
+	if err != nil {
+		return err
+	}
+	defer libc.Close()
+
+	sha, err := libc.SHA256()
+	if err != nil {
+		return err
+	}
+	buildID, err := libc.BuildID()
+	if err != nil {
+		log.Printf("[경고] %s build-id 읽기 실패: %v\n", path, err)
+	}
+
+	t := storage.NewLibcTable(path, buildID, sha, config.AnalyzerVersion)
+	if !*forceFlag {
+		old, err := store.LoadLibcTable(ctx, t.Key)
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return err
+		}
+		if old != nil && old.AnalyzerVersion == config.AnalyzerVersion {
+			t = old
+		}
+	}
+
+	symbols, err := libc.ExtractDynamicSymbols()
+	if err != nil {
+		return err
+	}
+	catalog, err := newCatalog(ctx, libc, t)
+	if err != nil {
+		return err
+	}
+	wrappers := make(map[string]struct{})
+	for _, name := range catalog.Filter(symbols) {
+		wrappers[name] = struct{}{}
+	}
+
+	// --timeout 은 추적에만 적용하고, 그때까지 채운 표는 기본 컨텍스트로 저장 (다음 prewarm이 이어서 추적)
+	before := len(t.Syscalls)
+	traceCtx, cancel := withTimeout(ctx)
+	defer cancel()
+	result := processor.BuildSyscallMap(traceCtx, libc, wrappers, processor.Options{
+		Concurrency:    *concurrencyFlag,
+		LibcTable:      t.Syscalls,
+		LibcReasons:    t.Reasons,
+		LibcConfidence: t.Confidence,
+	})
+	t.UpdatedAt = time.Now().UTC()
+	if err := store.SaveLibcTable(ctx, t); err != nil {
+		return err
+	}
+	log.Printf("  [성공] %s (%s): 래퍼 %d개 중 %d개 새로 추적, 표 저장 완료 (%s)\n", path, t.Key, len(wrappers), len(t.Syscalls)-before, libc.Stats())
+	if result.Incomplete {
+		return fmt.Errorf("추적 미완료 (%w): 래퍼 %d개 남음", result.Err, len(result.Unfinished))
+	}
+	return nil
+}
@@ -5,43 +5,94 @@ package main
 import (
 	"context"
 	"debug/elf"
-	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
 	"ips_bpf/static-analyzer/pkg/analyzer"
-	"ips_bpf/static-analyzer/pkg/config"    // [신규]
+	"ips_bpf/static-analyzer/pkg/asmanalysis"
+	"ips_bpf/static-analyzer/pkg/config" // [신규]
+	"ips_bpf/static-analyzer/pkg/export"
 	"ips_bpf/static-analyzer/pkg/processor" // [신규]
+	"ips_bpf/static-analyzer/pkg/report"
 	"ips_bpf/static-analyzer/pkg/storage"
+	"ips_bpf/static-analyzer/pkg/syscalls"
 	"log"
 	"os"
+	"os/signal"
+	"sort"
 	"strings"
+	"syscall"
+	"time"
+)
+
+// 명령행 옵션
+var (
+	formatFlag         = flag.String("format", "json", "출력 형식 (json, map, seccomp-crd, ebpf, bitmap-json, bitmap-bin, falco, tetragon, capabilities)")
+	outputFlag         = flag.String("o", "", "결과를 저장할 파일 경로 (기본: 표준 출력)")
+	profileNameFlag    = flag.String("profile-name", "", "seccomp-crd/falco/tetragon: 프로파일(정책) 이름 (기본: 분석 대상 파일명)")
+	namespaceFlag      = flag.String("namespace", "", "seccomp-crd: SeccompProfile 네임스페이스")
+	baseProfileFlag    = flag.String("base-profile", export.DefaultBaseProfile, "seccomp-crd: baseProfileName (빈 값이면 베이스 없이 분석 결과만 허용)")
+	goPackageFlag      = flag.String("go-package", "ipsbpf", "ebpf: 생성할 Go 로더 스텁의 패키지 이름")
+	imageFlag          = flag.String("image", "", "falco/tetragon: 정책을 적용할 컨테이너 이미지 저장소")
+	binaryPathFlag     = flag.String("binary-path", "", "falco/tetragon: 컨테이너 안의 실행 파일 경로 (기본: 분석 대상 경로)")
+	tetragonActionFlag = flag.String("tetragon-action", "Post", "tetragon: 허용 목록 밖 시스템 콜에 대한 action (Post, Sigkill)")
+	storeFlag          = flag.String("store", config.LoadStoreKind(), "결과 저장소 (redis, file, none) [CCSL_STORE]")
+	storeDirFlag       = flag.String("store-dir", config.LoadStoreDir(), "file 저장소 디렉터리 [CCSL_STORE_DIR]")
+	noStoreFlag        = flag.Bool("no-store", false, "결과를 저장하지 않음 (--store=none 과 동일)")
+	forceFlag          = flag.Bool("force", false, "저장된 결과(캐시)가 있어도 다시 분석")
+	catalogFlag        = flag.String("catalog", string(analyzer.SourceBundled), "시스템 콜 래퍼 목록 소스 (bundled, man, file, libc), 읽지 못하면 bundled 사용")
+	catalogFileFlag    = flag.String("catalog-file", "", "--catalog=file 일 때 읽을 래퍼 목록 파일 (한 줄에 이름 하나)")
+	bannerFlag         = flag.Bool("banner", false, "시작 배너 출력")
+	timeoutFlag        = flag.Duration("timeout", 0, "분석 시간 제한 (예: 10m, 0: 제한 없음), 넘으면 부분 결과를 출력하고 종료 코드 2")
+	concurrencyFlag    = flag.Int("concurrency", 0, "동시에 추적할 래퍼 수 (0: CPU 수)")
+	minConfidenceFlag  = flag.String("min-confidence", asmanalysis.ConfidenceHeuristicAlias.String(), "허용 목록(Redis Set, seccomp 등)에 넣을 최소 신뢰도 (exact, propagated, heuristic-alias, transitive)")
+	dropDeadFlag       = flag.Bool("drop-dead-imports", true, "진입점(e_entry, 생성자, 소멸자, export 함수)에서 도달 가능한 호출 지점이 없는 래퍼를 결과에서 빼고 unreachable 로 보고 (false: dead_import 표시만)")
+	storeRetriesFlag   = flag.Int("store-retries", storage.DefaultRetryPolicy.Attempts, "저장소 연결/쓰기 최대 시도 횟수 (1: 재시도하지 않음)")
 )
 
 func main() {
-	// 프로그램 인자 존재하는지 확인 (프로그램 이름 + 파일 경로)하고 없으면 사용법 출력
-	if len(os.Args) < 2 {
-		fmt.Println("사용법: go run cmd/static-analyzer/main.go <ELF 파일 경로>")
-		os.Exit(1)
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "사용법: go run cmd/static-analyzer/main.go [옵션] <ELF 파일 경로>")
+		fmt.Fprintln(os.Stderr, "       go run cmd/static-analyzer/main.go delete [옵션] <sha256 | ELF 파일 경로>...")
+		fmt.Fprintln(os.Stderr, "       go run cmd/static-analyzer/main.go recompute [옵션]")
+		fmt.Fprintln(os.Stderr, "       go run cmd/static-analyzer/main.go prewarm [옵션] <libc 경로>...")
+		flag.PrintDefaults()
 	}
 
-	/* //context.Background()를 부모로 하여 3초 타임아웃을 설정
-	const timeoutDuration = 3 * time.Second
-	ctx, cancel := context.WithTimeout(context.Background(), timeoutDuration)*/
+	// [신규] 하위 명령 (delete, recompute, prewarm)
+	if runSubcommand() {
+		return
+	}
+	flag.Parse()
 
-	//레디스 호출
-	ctx := context.Background() //기본 컨텍스트 생성
-	redisAddr := config.LoadRedisAddr()
-	redisPassword := config.LoadRedisPassword() // config.go에서 "CCSL_REDIS_PASSWORD"를 읽습니다.
-	rdb, err := storage.NewRedisClient(redisAddr, redisPassword)
+	// 프로그램 인자 존재하는지 확인 (파일 경로)하고 없으면 사용법 출력
+	if flag.NArg() < 1 {
+		flag.Usage()
+		os.Exit(1)
+	}
+	if _, ok := outputRenderers[*formatFlag]; !ok {
+		log.Fatalf("지원하지 않는 출력 형식: %s", *formatFlag)
+	}
+	minConfidence, err := asmanalysis.ParseConfidence(*minConfidenceFlag)
 	if err != nil {
-		log.Fatalf("Redis 클라이언트 생성 오류: %v", err)
+		log.Fatalf("--min-confidence: %v", err)
+	}
+	applyStoreFlags()
+	if *bannerFlag {
+		printBanner(os.Stderr)
 	}
-	defer rdb.Close()
-	fmt.Printf("Redis 클라이언트 생성 완료: %s\n", redisAddr)
+
+	// [수정] SIGINT/SIGTERM(Job 종료) 시 취소되는 기본 컨텍스트
+	// --timeout 은 분석 단계(캐시 조회 ~ 래퍼 매핑)에만 적용하고, 부분 결과 출력/저장은 기본 컨텍스트로 수행
+	ctx, stop := newContext()
+	defer stop()
+	analysisCtx, cancel := withTimeout(ctx)
+	defer cancel()
 
 	// 첫 번째 인자를 파일 경로로 사용
-	filePath := os.Args[1]
-	fmt.Printf("분석 대상 파일: %s\n", filePath)
-	fmt.Println("----------------------------------------")
+	filePath := flag.Arg(0)
+	fmt.Fprintf(os.Stderr, "분석 대상 파일: %s\n", filePath)
+	fmt.Fprintln(os.Stderr, "----------------------------------------")
 
 	// --- 1. 대상 ELF 분석기 초기화 ---
 	elfAnalyzer, err := analyzer.New(filePath)
@@ -49,16 +100,237 @@ func main() {
 		log.Fatalf("대상 ELF 분석기 생성 오류: %v", err)
 	}
 	defer elfAnalyzer.Close()
+	// [신규] 역어셈(x86-64 모드)과 시스템 콜 번호 표가 x86_64 전용이므로 다른 아키텍처 대상은 분석하지 않음
+	if err := export.CheckArch(elfAnalyzer.Arch()); err != nil {
+		log.Fatalf("대상 ELF 오류: %v", err)
+	}
 
 	// --- 2. Libc 분석기 초기화 ---
 	// [수정] config.LibcPath 사용
-	fmt.Printf("Glibc 라이브러리 분석 중: %s\n", config.LibcPath)
+	fmt.Fprintf(os.Stderr, "Glibc 라이브러리 분석 중: %s\n", config.LibcPath)
 	libcAnalyzer, err := analyzer.New(config.LibcPath)
 	if err != nil {
 		log.Fatalf("Libc 분석기 생성 오류: %v", err)
 	}
 	defer libcAnalyzer.Close()
+	if err := export.CheckArch(libcAnalyzer.Arch()); err != nil {
+		log.Fatalf("Libc 오류: %v", err)
+	}
+
+	// [신규] 결과 저장 키와 메타데이터에 쓸 식별 정보 (sha256, build-id)
+	meta, err := buildMeta(elfAnalyzer, libcAnalyzer)
+	if err != nil {
+		log.Fatalf("분석 대상 식별 정보 계산 오류: %v", err)
+	}
+	fmt.Fprintf(os.Stderr, "sha256: %s, build-id: %s, libc build-id: %s\n", meta.SHA256, meta.BuildID, meta.LibcBuildID)
+
+	// --- [신규] 결과 캐시 조회: 같은 대상/libc/분석기 버전의 결과가 저장되어 있으면 분석 생략 ---
+	// 캐시에 없으면 libc 심볼별 시스템 콜 표(같은 libc build-id 공용)를 읽어 이미 추적한 래퍼는 재사용
+	// [신규] --format json 으로 출력할 보고서 (래퍼별 후보 주소, 미해결 래퍼와 이유, Tracepoint 여부)
+	cached := lookupCache(analysisCtx, meta)
+	cacheHit := cached != nil
+	var libcTable *storage.LibcTable
+	var rep *report.Report
+	if cacheHit {
+		meta.AnalyzedAt = cached.AnalyzedAt
+		rep = report.New(meta, elfAnalyzer.Arch(), libcAnalyzer.Arch())
+		rep.AddCached(cached.Wrappers, cached.Confidence)
+	} else {
+		libcTable = loadLibcTable(analysisCtx, meta)
+		traced, hadWrappers := len(libcTable.Syscalls), libcTable.Wrappers != nil
+		result := analyzeWrappers(analysisCtx, elfAnalyzer, libcAnalyzer, libcTable, minConfidence)
+		meta.AnalyzedAt = time.Now().UTC() // 보고서와 저장 결과의 분석 시각을 맞춤
+		rep = report.New(meta, elfAnalyzer.Arch(), libcAnalyzer.Arch())
+		if result == nil {
+			// 분석할 심볼/래퍼가 없어도 --format json 은 항상 보고서 하나를 출력 (빈 wrappers/syscalls, 저장하지 않음)
+			emitReport(rep)
+			return
+		}
+		rep.AddResult(result)
+		if len(libcTable.Syscalls) == traced && (libcTable.Wrappers != nil) == hadWrappers {
+			libcTable = nil // 새로 추적한 심볼이나 새로 계산한 래퍼 목록이 없으면 다시 저장하지 않음
+		}
+	}
+
+	// [신규] 대상 바이너리에서 래퍼를 호출하는 지점(호출한 함수, 주소)과 인자 상수 복원 (socket domain, clone flags 등)
+	// 캐시에는 저장하지 않으므로 캐시 적중 시에도 매번 계산 (대상 바이너리의 실행 섹션만 스캔, PLT 제외)
+	// 진입점에서 도달 가능한 호출 지점이 없는 래퍼는 저장 전에 결과에서 빼고 unreachable 로 따로 보고 (--drop-dead-imports=false 로 끔)
+	// 허용 목록에서 뺀 래퍼는 저장 결과의 meta 에도 기록하여 나중에 검토할 수 있게 함
+	if dropped := addCallSites(analysisCtx, elfAnalyzer, rep, *dropDeadFlag); !cacheHit {
+		meta.DroppedImports = dropped
+	}
+	redisMap, incomplete := rep.SyscallMap(), rep.Incomplete
+
+	// --- 6. [신규] 결과 저장소에 분석 결과 저장 ---
+	// 분석이 끝난 뒤에 연결하므로 저장소 장애가 있어도 분석 결과는 아래에서 그대로 출력됨
+	// 캐시에서 읽은 결과도 다시 저장하여 경로가 가리키는 sha256 을 갱신 (이전 버전으로 되돌아간 경우에도 변경 알림이 발행되도록)
+	// 미완료(부분) 결과는 허용 목록을 잘못 줄일 수 있으므로 저장하지 않고, 그때까지 채운 libc 표만 저장
+	fmt.Fprintln(os.Stderr, "----------------------------------------")
+	var a *storage.Analysis
+	switch {
+	case cacheHit:
+		a = cached
+		a.Path = meta.Path
+	case !incomplete:
+		a = storage.NewAnalysis(meta, redisMap)
+		a.Confidence = rep.ConfidenceMap()
+	}
+	storeErr := saveAnalysis(ctx, a, libcTable)
+	if storeErr != nil {
+		log.Printf("[경고] 결과 저장 실패 (분석 결과는 아래에 출력됨): %v\n", storeErr)
+	}
+
+	// --- 7. [신규] 래퍼 커버리지 요약과 권한(capability) 보고서 ---
+	printCoverage(rep)
+	printCapabilityReport(redisMap)
+
+	// --- 8. 최종 결과 출력 (--format 에 따라 보고서 JSON 또는 매니페스트) ---
+	emitReport(rep)
+
+	// 저장 실패와 미완료는 결과를 출력한 뒤 종료 코드로 알림 (Job 실패로 표시)
+	if storeErr != nil {
+		os.Exit(1)
+	}
+	if incomplete {
+		os.Exit(2)
+	}
+}
+
+// emitReport : 보고서를 --format 에 따라 변환하여 표준 출력(또는 -o)에 기록
+// 표준 출력에는 결과만 기록하고, 진행 로그는 모두 표준 에러로 출력됨
+func emitReport(rep *report.Report) {
+	fmt.Fprintln(os.Stderr, "----------------------------------------")
+	fmt.Fprintf(os.Stderr, "최종 매핑 결과 출력 (형식: %s):\n", *formatFlag)
+	outFiles, err := outputRenderers[*formatFlag](rep)
+	if err != nil {
+		log.Fatalf("결과 변환 오류: %v", err)
+	}
+	if err := writeOutput(*outputFlag, outFiles); err != nil {
+		log.Fatalf("%v", err)
+	}
+}
+
+// addCallSites : 보고서의 래퍼와 인자 명세가 있는 래퍼의 호출 지점을 찾아 보고서에 추가 (실패해도 허용 목록에는 영향 없음)
+// dropDead 이면 도달 가능한 호출 지점이 없는 래퍼를 결과에서 빼고, 그중 허용 목록에서 빠진 래퍼 이름을 반환
+func addCallSites(ctx context.Context, elfAnalyzer *analyzer.ELFAnalyzer, rep *report.Report, dropDead bool) []string {
+	names := make(map[string]struct{})
+	for _, w := range rep.Wrappers {
+		names[w.Name] = struct{}{}
+	}
+	for _, u := range rep.Unresolved {
+		names[u.Name] = struct{}{}
+	}
+	for _, name := range syscalls.ArgSpecWrappers() {
+		names[name] = struct{}{}
+	}
+	sites, err := elfAnalyzer.ImportCallSites(ctx, names)
+	if err != nil {
+		log.Printf("[경고] 래퍼 호출 지점 분석 실패: %v\n", err)
+		return nil
+	}
+	rep.AddCallSites(sites)
+	rep.AddArguments(sites)
+	defer printCallSites(rep)
+
+	var dead []string
+	for _, w := range rep.Wrappers {
+		if w.DeadImport {
+			dead = append(dead, w.Name)
+		}
+	}
+	if len(dead) == 0 {
+		return nil
+	}
+	if !dropDead {
+		fmt.Fprintf(os.Stderr, "도달 가능한 호출 지점이 없는 래퍼 %d개 (--drop-dead-imports=false 로 허용 목록에 유지): %s\n", len(dead), strings.Join(dead, ", "))
+		return nil
+	}
+	dropped := rep.SeparateUnreachable()
+	// 호출 그래프가 놓친 호출(계산된 포인터, dlsym 등)이면 런타임에 차단되므로 경고로 남김
+	log.Printf("[경고] 도달 가능한 호출 지점이 없어 결과에서 뺀 래퍼 %d개: %s\n", len(dead), strings.Join(dead, ", "))
+	if len(dropped) > 0 {
+		log.Printf("[경고] 허용 목록에서 빠진 래퍼 %d개 (필요하면 --drop-dead-imports=false): %s\n", len(dropped), strings.Join(dropped, ", "))
+	}
+	return dropped
+}
 
+// printCallSites : 시스템 콜별로 래퍼를 호출하는 함수와 주소 출력 (어느 코드 경로가 execve 등을 쓰는지 검토용)
+func printCallSites(rep *report.Report) {
+	bySyscall := make(map[string][]string)
+	for _, w := range rep.Wrappers {
+		for _, site := range w.CallSites {
+			caller := site.Function
+			if caller == "" {
+				caller = "?"
+			}
+			bySyscall[w.Syscall] = append(bySyscall[w.Syscall], fmt.Sprintf("%s (%s %s)", caller, w.Name, site.Address))
+		}
+	}
+	if len(bySyscall) == 0 {
+		return
+	}
+	fmt.Fprintln(os.Stderr, "----------------------------------------")
+	fmt.Fprintln(os.Stderr, "시스템 콜별 호출 지점:")
+	syscallNames := make([]string, 0, len(bySyscall))
+	for name := range bySyscall {
+		syscallNames = append(syscallNames, name)
+	}
+	sort.Strings(syscallNames)
+	for _, name := range syscallNames {
+		fmt.Fprintf(os.Stderr, "  %s <- %s\n", name, strings.Join(bySyscall[name], ", "))
+	}
+	for _, arg := range rep.Arguments {
+		fmt.Fprintf(os.Stderr, "인자 복원: %s 호출 지점 %d개\n", arg.Wrapper, len(arg.CallSites))
+	}
+}
+
+// printCoverage : 허용 목록에 들어가지 못한 래퍼를 reason 코드별로 요약한 표 출력
+func printCoverage(rep *report.Report) {
+	fmt.Fprintln(os.Stderr, "----------------------------------------")
+	fmt.Fprintf(os.Stderr, "래퍼 커버리지: %d개 중 %d개 허용 목록에 포함\n", rep.Coverage.Wrappers, rep.Coverage.Allowed)
+	summary := rep.ReasonSummary()
+	if len(summary) == 0 {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "  %-20s %6s  %s\n", "이유", "래퍼 수", "래퍼")
+	for _, c := range summary {
+		fmt.Fprintf(os.Stderr, "  %-20s %6d  %s\n", c.Reason, len(c.Wrappers), strings.Join(c.Wrappers, ", "))
+	}
+}
+
+// printCapabilityReport : 발견된 시스템 콜이 요구할 수 있는 capability와 위험 시스템 콜 목록 출력
+func printCapabilityReport(syscallMap map[string]string) {
+	report := syscalls.BuildCapabilityReport(export.AllowList(syscallMap))
+	sc := export.SuggestSecurityContext(report)
+
+	fmt.Fprintln(os.Stderr, "----------------------------------------")
+	fmt.Fprintln(os.Stderr, "권한(capability) 분석:")
+	if len(report.Capabilities) == 0 {
+		fmt.Fprintln(os.Stderr, "  항상 필요한 capability 없음")
+	}
+	for _, c := range report.Capabilities {
+		fmt.Fprintf(os.Stderr, "  - %s: %s\n", c.Capability, strings.Join(c.Syscalls, ", "))
+	}
+	if len(report.MayRequire) > 0 {
+		fmt.Fprintln(os.Stderr, "인자에 따라 필요할 수 있는 capability (제안값에 넣지 않음):")
+		for _, c := range report.MayRequire {
+			fmt.Fprintf(os.Stderr, "  - %s: %s\n", c.Capability, strings.Join(c.Syscalls, ", "))
+		}
+	}
+	if len(report.Dangerous) > 0 {
+		fmt.Fprintln(os.Stderr, "위험 시스템 콜:")
+		for _, d := range report.Dangerous {
+			fmt.Fprintf(os.Stderr, "  - %s [%s]\n", d.Name, d.Category)
+		}
+	}
+	fmt.Fprintf(os.Stderr, "제안 securityContext.capabilities: drop=%v add=%v\n", sc.Capabilities.Drop, sc.Capabilities.Add)
+}
+
+// analyzeWrappers : 대상 ELF의 동적 심볼에서 시스템 콜 래퍼를 골라 libc에서 커널 시스템 콜로 매핑
+// 분석할 심볼/래퍼가 없으면 nil
+// libcTable은 libc 심볼별 추적 결과와 --catalog=libc 래퍼 목록 캐시 (새로 계산한 값은 여기에 기록)
+// minConfidence 보다 신뢰도가 낮은 래퍼는 허용 목록(Result.Wrappers)에서 제외
+func analyzeWrappers(ctx context.Context, elfAnalyzer, libcAnalyzer *analyzer.ELFAnalyzer, libcTable *storage.LibcTable, minConfidence asmanalysis.Confidence) *processor.Result {
 	// --- 3. 대상 ELF에서 동적 심볼 추출 ---
 	symbols, err := elfAnalyzer.ExtractDynamicSymbols()
 	if err != nil {
@@ -67,25 +339,29 @@ func main() {
 		}
 	}
 	if len(symbols) == 0 {
-		fmt.Println("이 파일은 심볼 정보를 포함하지 않습니다.")
-		os.Exit(0) // 분석할 심볼이 없으므로 종료
+		fmt.Fprintln(os.Stderr, "이 파일은 심볼 정보를 포함하지 않습니다.")
+		return nil // 분석할 심볼이 없음
 	}
 	// ... (심볼 목록 출력은 가독성을 위해 생략) ...
 
-	// --- 4. syscall_filter.go를 사용해 "관심 있는" 래퍼 함수 필터링 ---
-	expectSyscalls := analyzer.FilterSyscalls(symbols)
+	// --- 4. 래퍼 목록(카탈로그)을 사용해 "관심 있는" 래퍼 함수 필터링 ---
+	catalog, err := newCatalog(ctx, libcAnalyzer, libcTable)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	expectSyscalls := catalog.Filter(symbols)
 	if len(expectSyscalls) == 0 {
-		fmt.Println("의존하는 시스템 콜 래퍼를 찾지 못했습니다.")
-		os.Exit(0) // 분석할 래퍼가 없으므로 종료
+		fmt.Fprintln(os.Stderr, "의존하는 시스템 콜 래퍼를 찾지 못했습니다.")
+		return nil // 분석할 래퍼가 없음
 	}
-	fmt.Printf("의존하는 시스템 콜 래퍼 %d개 발견:\n", len(expectSyscalls))
+	fmt.Fprintf(os.Stderr, "의존하는 시스템 콜 래퍼 %d개 발견:\n", len(expectSyscalls))
 	for _, sym := range expectSyscalls {
-		fmt.Printf("- %s\n", sym)
+		fmt.Fprintf(os.Stderr, "- %s\n", sym)
 	}
-	fmt.Println("----------------------------------------")
+	fmt.Fprintln(os.Stderr, "----------------------------------------")
 
 	// --- 5. [신규] 핵심 로직을 Processor에 위임 ---
-	fmt.Println("래퍼 함수 $\to$ 커널 시스템 콜 패턴 매핑 중...")
+	fmt.Fprintln(os.Stderr, "래퍼 함수 $\to$ 커널 시스템 콜 패턴 매핑 중...")
 
 	// 중복 제거 (예: read@...가 여러 개 있을 수 있음)
 	uniqueWrappers := make(map[string]struct{})
@@ -95,46 +371,197 @@ func main() {
 	}
 
 	// 역어셈 및 분석을 통해 매핑 생성
-	redisMap := processor.BuildSyscallMap(libcAnalyzer, uniqueWrappers)
+	start := time.Now()
+	result := processor.BuildSyscallMap(ctx, libcAnalyzer, uniqueWrappers, processor.Options{
+		Concurrency:    *concurrencyFlag,
+		LibcTable:      libcTable.Syscalls,
+		LibcReasons:    libcTable.Reasons,
+		LibcConfidence: libcTable.Confidence,
+		MinConfidence:  minConfidence,
+	})
+	if result.Incomplete {
+		log.Printf("[경고] 분석 미완료 (%v): 래퍼 %d개 중 %d개를 추적하지 못했습니다: %s\n",
+			result.Err, len(uniqueWrappers), len(result.Unfinished), strings.Join(result.Unfinished, ", "))
+	}
+	fmt.Fprintf(os.Stderr, "래퍼 %d개 매핑 완료: %s (%s)\n", len(uniqueWrappers), time.Since(start), libcAnalyzer.Stats())
+	return result
+}
+
+// newCatalog : --catalog 설정으로 시스템 콜 래퍼 목록 생성 (읽지 못하면 bundled 목록으로 대체)
+// --catalog=libc 이면 libc 표에 캐시된 목록을 쓰고, 없으면 libc를 훑어 계산한 뒤 표에 기록
+func newCatalog(ctx context.Context, libc *analyzer.ELFAnalyzer, libcTable *storage.LibcTable) (*analyzer.SyscallCatalog, error) {
+	opts := analyzer.CatalogOptions{
+		Source:   analyzer.CatalogSource(*catalogFlag),
+		Path:     *catalogFileFlag,
+		Fallback: true,
+		Log:      os.Stderr,
+	}
+	if opts.Source == analyzer.SourceLibc {
+		opts.Names = libcSyscallFunctions(ctx, libc, libcTable)
+	}
+	return analyzer.NewSyscallCatalog(opts)
+}
 
-	// [이동] Redis 저장 로직 (주석 처리됨)
+// newContext : SIGINT/SIGTERM 을 받으면 취소되는 컨텍스트
+func newContext() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+}
 
-	// --- 6. [신규] Redis에 K-V 데이터 삽입 ---
-	fmt.Println("----------------------------------------")
-	fmt.Println("Redis에 래퍼 $\to$ 커널 매핑 및 Set 저장 중...")
-	pipe := rdb.Pipeline()
+// withTimeout : --timeout 이 있으면 기한을 둔 하위 컨텍스트
+func withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if *timeoutFlag <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, *timeoutFlag)
+}
 
-	// Set에 추가할 시스템 콜 목록을 별도로 수집
-	var callableSyscalls []interface{}
+// lookupCache : 저장소에서 같은 입력으로 만든 결과를 찾음, 없으면 nil (--force 또는 --store=none 이면 조회하지 않음)
+// 저장소에 연결하지 못해도 분석을 진행하면 되므로 한 번만 시도하고 경고만 남김
+func lookupCache(ctx context.Context, meta storage.Meta) *storage.Analysis {
+	if *forceFlag || *storeFlag == storage.KindNone {
+		return nil
+	}
 
-	for wrapperName, kernelName := range redisMap {
-		if kernelName != "" {
-			// 1. 기존의 개별 K-V 데이터 저장 (Keep this for debugging/lookup)
-			pipe.Set(ctx, wrapperName, kernelName, 0)
+	store, err := openStoreWithRetry(ctx, storage.RetryPolicy{Attempts: 1})
+	if err != nil {
+		log.Printf("[경고] 캐시 조회를 위한 저장소 연결 실패, 분석을 진행합니다: %v\n", err)
+		return nil
+	}
+	defer store.Close()
 
-			// 2. [추가] Set에 커널 시스템 콜 이름만 추가 (웹 서비스에서 사용)
-			callableSyscalls = append(callableSyscalls, kernelName)
-		}
+	cached, err := store.Load(ctx, meta.SHA256)
+	if errors.Is(err, storage.ErrNotFound) {
+		fmt.Fprintln(os.Stderr, "캐시 없음: 분석을 진행합니다.")
+		return nil
+	}
+	if err != nil {
+		log.Printf("[경고] 캐시 조회 실패, 분석을 진행합니다: %v\n", err)
+		return nil
 	}
+	if ok, reason := meta.SameInputs(cached.Meta); !ok {
+		fmt.Fprintf(os.Stderr, "캐시 무효 (%s): 분석을 진행합니다.\n", reason)
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "캐시 적중: %s 에 분석된 결과를 사용합니다. (다시 분석하려면 --force)\n", cached.AnalyzedAt.Format(time.RFC3339))
+	return cached
+}
 
-	// [추가] Set에 모든 시스템 콜 이름을 한 번에 저장 (Set Key는 SyscallService에 정의된 값)
-	if len(callableSyscalls) > 0 {
-		pipe.SAdd(ctx, "cluster_callable_syscalls", callableSyscalls...) // SAdd 사용
+// buildMeta : 분석 대상과 libc의 식별 정보로 저장용 메타데이터 생성
+func buildMeta(target, libc *analyzer.ELFAnalyzer) (storage.Meta, error) {
+	meta := storage.Meta{
+		Path:            target.Path(),
+		LibcPath:        libc.Path(),
+		AnalyzerVersion: config.AnalyzerVersion,
+		MinConfidence:   *minConfidenceFlag,
+		DropDeadImports: *dropDeadFlag,
 	}
 
-	_, err = pipe.Exec(ctx)
+	var err error
+	if meta.SHA256, err = target.SHA256(); err != nil {
+		return meta, err
+	}
+	// build-id가 없거나 노트를 읽지 못해도 분석은 가능하므로 경고만 남김
+	if meta.BuildID, err = target.BuildID(); err != nil {
+		log.Printf("[경고] 분석 대상 build-id 읽기 실패: %v\n", err)
+	}
+	if meta.LibcBuildID, err = libc.BuildID(); err != nil {
+		log.Printf("[경고] libc build-id 읽기 실패: %v\n", err)
+	}
+	if meta.LibcSHA256, err = libc.SHA256(); err != nil {
+		return meta, err
+	}
+	return meta, nil
+}
+
+// applyStoreFlags : 저장소 옵션 확인 (--no-store 는 --store=none 으로, --store-retries 는 1 이상)
+func applyStoreFlags() {
+	if *noStoreFlag {
+		*storeFlag = storage.KindNone
+	}
+	if *storeRetriesFlag < 1 {
+		log.Fatalf("--store-retries 는 1 이상이어야 합니다 (1: 재시도하지 않음): %d", *storeRetriesFlag)
+	}
+}
+
+// openStore : --store 설정에 맞는 결과 저장소 생성
+func openStore(ctx context.Context) (storage.ResultStore, error) {
+	retry := storage.DefaultRetryPolicy
+	retry.Attempts = *storeRetriesFlag
+	return openStoreWithRetry(ctx, retry)
+}
+
+// openStoreWithRetry : 재시도 정책을 지정하여 결과 저장소 생성
+func openStoreWithRetry(ctx context.Context, retry storage.RetryPolicy) (storage.ResultStore, error) {
+	opts := storage.Options{Kind: *storeFlag, Dir: *storeDirFlag, Retry: retry}
+	if opts.Kind == storage.KindRedis {
+		opts.RedisAddr = config.LoadRedisAddr()
+		opts.RedisPassword = config.LoadRedisPassword() // config.go에서 "CCSL_REDIS_PASSWORD"를 읽습니다.
+	}
+	return storage.Open(ctx, opts)
+}
+
+// saveAnalysis : 저장소에 연결(지연 연결)하여 분석 결과 저장, --store=none 이면 아무것도 하지 않음
+// a가 nil이면(미완료 분석) 결과는 저장하지 않음
+// libcTable이 nil이 아니면 갱신된 libc 심볼별 시스템 콜 표도 함께 저장
+func saveAnalysis(ctx context.Context, a *storage.Analysis, libcTable *storage.LibcTable) error {
+	if *storeFlag == storage.KindNone {
+		fmt.Fprintln(os.Stderr, "결과 저장 생략 (--store=none)")
+		return nil
+	}
+	if a == nil && libcTable == nil {
+		fmt.Fprintln(os.Stderr, "결과 저장 생략 (분석 미완료)")
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "결과 저장소(%s)에 래퍼 $\to$ 커널 매핑 및 Set 저장 중...\n", *storeFlag)
+	store, err := openStore(ctx)
 	if err != nil {
-		log.Printf("[경고] Redis 파이프라인 실행 실패: %v\n", err)
+		return fmt.Errorf("결과 저장소 연결 실패: %w", err)
+	}
+	defer store.Close()
+
+	if a != nil {
+		diff, err := store.Save(ctx, a)
+		if err != nil {
+			return err
+		}
+		log.Println("  [성공] 결과 저장소에 데이터 저장 완료.")
+		printSyscallDiff(diff)
 	} else {
-		log.Println("  [성공] Redis에 데이터 저장 완료.")
+		fmt.Fprintln(os.Stderr, "결과 저장 생략 (분석 미완료), libc 표만 저장")
 	}
 
-	// --- 7. 최종 JSON 출력 (Redis K-V와 동일한 맵) ---
-	fmt.Println("----------------------------------------")
-	fmt.Println("최종 매핑 결과 JSON (Redis K-V) 출력:")
-	jsonData, err := json.MarshalIndent(redisMap, "", "  ") // redisMap을 출력
-	if err != nil {
-		log.Fatalf("JSON 변환 오류: %v", err)
+	if libcTable != nil {
+		libcTable.UpdatedAt = time.Now().UTC()
+		// libc 표는 캐시일 뿐이므로 저장에 실패해도 Job을 실패시키지 않음
+		if err := store.SaveLibcTable(ctx, libcTable); err != nil {
+			log.Printf("[경고] libc 표 저장 실패: %v\n", err)
+		} else {
+			log.Printf("  [성공] libc 표 저장 완료 (%s, 심볼 %d개)\n", libcTable.Key, len(libcTable.Syscalls))
+		}
+	}
+	return nil
+}
+
+// printSyscallDiff : 이전 저장 결과 대비 시스템 콜 변화 출력 (예: "syscalltest2 에 ptrace 추가")
+func printSyscallDiff(diff *storage.SyscallDiff) {
+	if diff != nil && diff.Previous != "" {
+		fmt.Fprintf(os.Stderr, "  [변경] %s: 이전 버전(%s)과 비교\n", diff.Path, diff.Previous)
+	}
+	switch {
+	case diff == nil:
+		return
+	case diff.Change == storage.ChangeCreated:
+		fmt.Fprintf(os.Stderr, "  [변경] %s: 새로 등록됨 (시스템 콜 %d개)\n", diff.Path, len(diff.Added))
+	case !diff.Changed():
+		fmt.Fprintf(os.Stderr, "  [변경] %s: 이전 결과와 시스템 콜 집합 동일\n", diff.Path)
+	default:
+		if len(diff.Added) > 0 {
+			fmt.Fprintf(os.Stderr, "  [변경] %s: 추가된 시스템 콜 %s\n", diff.Path, strings.Join(diff.Added, ", "))
+		}
+		if len(diff.Removed) > 0 {
+			fmt.Fprintf(os.Stderr, "  [변경] %s: 제거된 시스템 콜 %s\n", diff.Path, strings.Join(diff.Removed, ", "))
+		}
 	}
-	fmt.Println(string(jsonData))
 }
@@ -0,0 +1,190 @@
+// cmd/static-analyzer/output.go
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"ips_bpf/static-analyzer/pkg/bpfgen"
+	"ips_bpf/static-analyzer/pkg/export"
+	"ips_bpf/static-analyzer/pkg/report"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+// outputFile은 렌더링된 결과 파일 하나
+// Name이 비어 있으면 단일 결과로 보고 -o 경로(또는 표준 출력)에 그대로 기록
+type outputFile struct {
+	Name string
+	Data []byte
+}
+
+// outputRenderer는 분석 보고서를 특정 형식의 파일(들)로 변환하는 함수
+// 보고서 외의 형식은 rep.SyscallMap() (Tracepoint가 있는 {wrapper: kernelSyscall} 맵)을 입력으로 사용
+type outputRenderer func(rep *report.Report) ([]outputFile, error)
+
+// outputRenderers : --format 값과 렌더러 매핑
+var outputRenderers = map[string]outputRenderer{
+	"json":         renderJSON,
+	"map":          renderMap,
+	"seccomp-crd":  renderSeccompCRD,
+	"ebpf":         renderEBPF,
+	"bitmap-json":  renderBitmapJSON,
+	"bitmap-bin":   renderBitmapBin,
+	"falco":        renderFalco,
+	"tetragon":     renderTetragon,
+	"capabilities": renderCapabilities,
+}
+
+// renderJSON : 버전이 있는 분석 보고서 (report.SchemaVersion)
+func renderJSON(rep *report.Report) ([]outputFile, error) {
+	return marshalSingle(rep)
+}
+
+// renderMap : 이전 json 출력 형식 ({wrapper: kernelSyscall} 맵, Redis K-V와 동일)
+func renderMap(rep *report.Report) ([]outputFile, error) {
+	return marshalSingle(rep.SyscallMap())
+}
+
+// renderSeccompCRD : security-profiles-operator SeccompProfile 매니페스트
+func renderSeccompCRD(rep *report.Report) ([]outputFile, error) {
+	targetPath, syscallMap := rep.Target.Path, rep.SyscallMap()
+	if *baseProfileFlag == "" {
+		log.Println("[경고] --base-profile 이 비어 있습니다. 컨테이너 런타임(runc)이 기동 중 호출하는 시스템 콜이 허용되지 않아 컨테이너가 시작되지 않을 수 있습니다.")
+	}
+	profile := export.BuildSeccompProfile(targetPath, syscallMap, export.SeccompOptions{
+		Name:            *profileNameFlag,
+		Namespace:       *namespaceFlag,
+		BaseProfileName: *baseProfileFlag,
+	})
+	return marshalSingle(profile)
+}
+
+// renderEBPF : 시스템 콜별 Tracepoint 핸들러 C 소스와 Go 로더 스텁 (-o 디렉터리에 저장)
+func renderEBPF(rep *report.Report) ([]outputFile, error) {
+	targetPath, syscallMap := rep.Target.Path, rep.SyscallMap()
+	out, err := bpfgen.Generate(syscallMap, bpfgen.Options{
+		Target:    targetPath,
+		GoPackage: *goPackageFlag,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range out.Skipped {
+		log.Printf("  [정보] %s: Tracepoint가 없어 핸들러 생성 생략\n", name)
+	}
+	return []outputFile{
+		{Name: "ips_syscalls.bpf.c", Data: out.BPFSource},
+		{Name: "ips_syscalls_loader.go", Data: out.GoLoader},
+	}, nil
+}
+
+// renderBitmapJSON : 아키텍처별 512비트 허용 비트맵 (words/hex/시스템 콜 목록)
+func renderBitmapJSON(rep *report.Report) ([]outputFile, error) {
+	targetPath, syscallMap := rep.Target.Path, rep.SyscallMap()
+	bitmap, err := buildBitmap(rep.Target.Arch, syscallMap)
+	if err != nil {
+		return nil, err
+	}
+	return marshalSingle(export.BitmapDocument{Target: targetPath, Bitmaps: []export.ArchBitmap{bitmap}})
+}
+
+// renderBitmapBin : x86_64 허용 비트맵 64바이트 원본 (bpf_map_update_elem 값으로 그대로 사용)
+func renderBitmapBin(rep *report.Report) ([]outputFile, error) {
+	bitmap, err := buildBitmap(rep.Target.Arch, rep.SyscallMap())
+	if err != nil {
+		return nil, err
+	}
+	return []outputFile{{Data: bitmap.Bitmap[:]}}, nil
+}
+
+// renderFalco : 허용 목록 밖 시스템 콜을 탐지하는 Falco 규칙 (YAML)
+func renderFalco(rep *report.Report) ([]outputFile, error) {
+	targetPath, syscallMap := rep.Target.Path, rep.SyscallMap()
+	rule, err := export.BuildFalcoRule(targetPath, syscallMap, export.FalcoOptions{
+		Scope: policyScope(),
+		Name:  *profileNameFlag,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return []outputFile{{Data: rule}}, nil
+}
+
+// renderTetragon : raw_syscalls:sys_enter 의 시스템 콜 id에 NotIn 셀렉터를 건 Tetragon TracingPolicy
+func renderTetragon(rep *report.Report) ([]outputFile, error) {
+	targetPath, syscallMap := rep.Target.Path, rep.SyscallMap()
+	policy, unknown, err := export.BuildTracingPolicy(targetPath, syscallMap, export.TetragonOptions{
+		Scope:  policyScope(),
+		Arch:   rep.Target.Arch,
+		Name:   *profileNameFlag,
+		Action: *tetragonActionFlag,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range unknown {
+		log.Printf("  [경고] %s: 시스템 콜 번호를 알 수 없어 TracingPolicy에서 제외됨\n", name)
+	}
+	return marshalSingle(policy)
+}
+
+// renderCapabilities : 시스템 콜이 암시하는 capability / 위험 시스템 콜 보고서와 제안 securityContext
+func renderCapabilities(rep *report.Report) ([]outputFile, error) {
+	targetPath, syscallMap := rep.Target.Path, rep.SyscallMap()
+	return marshalSingle(export.BuildCapabilityDocument(targetPath, syscallMap))
+}
+
+func policyScope() export.PolicyScope {
+	return export.PolicyScope{Image: *imageFlag, BinaryPath: *binaryPathFlag}
+}
+
+func buildBitmap(arch string, syscallMap map[string]string) (export.ArchBitmap, error) {
+	bitmap, unknown, err := export.BuildSyscallBitmap(arch, syscallMap)
+	if err != nil {
+		return export.ArchBitmap{}, err
+	}
+	for _, name := range unknown {
+		log.Printf("  [경고] %s: 시스템 콜 번호를 알 수 없거나 비트맵 범위를 벗어나 제외됨\n", name)
+	}
+	return bitmap, nil
+}
+
+func marshalSingle(v interface{}) ([]outputFile, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return []outputFile{{Data: append(data, '\n')}}, nil
+}
+
+// writeOutput : 렌더링된 결과를 기록
+// 단일 결과는 -o 경로(지정된 경우) 또는 표준 출력에, 여러 파일은 -o 디렉터리 아래에 저장
+func writeOutput(path string, files []outputFile) error {
+	if len(files) == 1 && files[0].Name == "" {
+		if path == "" {
+			_, err := os.Stdout.Write(files[0].Data)
+			return err
+		}
+		if err := os.WriteFile(path, files[0].Data, 0o644); err != nil {
+			return fmt.Errorf("결과 파일 쓰기 실패 (%s): %w", path, err)
+		}
+		fmt.Fprintf(os.Stderr, "결과 파일 저장 완료: %s\n", path)
+		return nil
+	}
+
+	if path == "" {
+		return fmt.Errorf("%s 형식은 여러 파일을 생성하므로 -o <디렉터리> 가 필요합니다", *formatFlag)
+	}
+	if err := os.MkdirAll(path, 0o755); err != nil {
+		return fmt.Errorf("결과 디렉터리 생성 실패 (%s): %w", path, err)
+	}
+	for _, f := range files {
+		filePath := filepath.Join(path, f.Name)
+		if err := os.WriteFile(filePath, f.Data, 0o644); err != nil {
+			return fmt.Errorf("결과 파일 쓰기 실패 (%s): %w", filePath, err)
+		}
+		fmt.Fprintf(os.Stderr, "결과 파일 저장 완료: %s\n", filePath)
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+// pkg/analyzer/cache.go
+package analyzer
+
+import (
+	"debug/elf"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/knightsc/gapstone"
+)
+
+// Stats는 ELFAnalyzer가 심볼 색인/역어셈에 쓴 시간과 횟수 (래퍼 추적 성능 측정용)
+type Stats struct {
+	IndexTime   time.Duration // 동적 심볼 색인 생성 (1회)
+	TextTime    time.Duration // .text 섹션 읽기 (1회)
+	EngineTime  time.Duration // Capstone 엔진 생성 누적 (동시 추적 시 고루틴 수만큼)
+	Engines     int           // 생성한 Capstone 엔진 수
+	DisasmTime  time.Duration // 함수 역어셈 누적 시간 (고루틴별 시간의 합)
+	Disasms     int           // 역어셈한 함수 수
+	DisasmBytes int           // 역어셈한 바이트 수
+}
+
+func (s Stats) String() string {
+	return fmt.Sprintf("색인 %s, .text 읽기 %s, 엔진 %d개 생성 %s, 역어셈 %d회/%d바이트 %s",
+		s.IndexTime, s.TextTime, s.Engines, s.EngineTime, s.Disasms, s.DisasmBytes, s.DisasmTime)
+}
+
+// elfCache : 래퍼마다 반복하던 작업(동적 심볼 읽기, .text 읽기, Capstone 엔진 생성)을 한 번만 하도록 보관
+// 색인과 .text는 생성 후 읽기 전용이므로 여러 고루틴에서 FindKernelSyscallPatterns 를 동시에 호출해도 됨
+type elfCache struct {
+	once    sync.Once
+	err     error
+	byName  map[string]elf.Symbol // 이름 -> 심볼 (같은 이름이 여러 버전이면 먼저 나온 것)
+	byAddr  map[uint64]string     // 주소 -> 심볼 이름 (같은 주소의 별칭이면 먼저 나온 것)
+	text    []byte                // .text 섹션 데이터
+	textAdr uint64                // .text 섹션 시작 주소
+
+	mu      sync.Mutex         // engines, all, stats 보호
+	engines []*gapstone.Engine // 쉬고 있는 엔진 (Capstone 핸들은 스레드 안전하지 않으므로 호출마다 하나씩 빌려 씀)
+	all     []*gapstone.Engine // 만든 모든 엔진 (Close에서 정리)
+	stats   Stats
+}
+
+// load : 동적 심볼 색인과 .text 데이터를 처음 사용할 때 한 번만 준비
+func (a *ELFAnalyzer) load() error {
+	c := &a.cache
+	c.once.Do(func() {
+		start := time.Now()
+		symbols, err := a.elfFile.DynamicSymbols()
+		if err != nil {
+			c.err = fmt.Errorf("동적 심볼 읽기 실패: %w", err)
+			return
+		}
+		c.byName = make(map[string]elf.Symbol, len(symbols))
+		c.byAddr = make(map[uint64]string, len(symbols))
+		for _, sym := range symbols {
+			if _, ok := c.byName[sym.Name]; !ok {
+				c.byName[sym.Name] = sym
+			}
+			if sym.Value != 0 {
+				if _, ok := c.byAddr[sym.Value]; !ok {
+					c.byAddr[sym.Value] = sym.Name
+				}
+			}
+		}
+		c.stats.IndexTime = time.Since(start)
+
+		start = time.Now()
+		textSect := a.Section(".text")
+		if textSect == nil {
+			c.err = fmt.Errorf(".text 섹션을 찾을 수 없습니다. (섹션이 스트립되었을 수 있습니다)")
+			return
+		}
+		if c.text, err = textSect.Data(); err != nil {
+			c.err = fmt.Errorf(".text 데이터 읽기 실패: %w", err)
+			return
+		}
+		c.textAdr = textSect.Addr
+		c.stats.TextTime = time.Since(start)
+	})
+	return c.err
+}
+
+// LookupSymbol : 동적 심볼을 이름으로 찾음
+func (a *ELFAnalyzer) LookupSymbol(name string) (elf.Symbol, bool) {
+	if a.load() != nil {
+		return elf.Symbol{}, false
+	}
+	sym, ok := a.cache.byName[name]
+	return sym, ok
+}
+
+// SymbolAt : 주소에 정의된 동적 심볼 이름을 찾음 (JMP/CALL 대상 확인용)
+func (a *ELFAnalyzer) SymbolAt(addr uint64) (string, bool) {
+	if a.load() != nil {
+		return "", false
+	}
+	name, ok := a.cache.byAddr[addr]
+	return name, ok
+}
+
+// acquireEngine : 쉬고 있는 상세(detail) 모드 Capstone 엔진을 빌리고, 없으면 새로 만듦
+// 다 쓴 엔진은 releaseEngine 으로 돌려주어 재사용 (동시 호출 수만큼만 생성됨)
+func (a *ELFAnalyzer) acquireEngine() (*gapstone.Engine, error) {
+	c := &a.cache
+	c.mu.Lock()
+	if n := len(c.engines); n > 0 {
+		engine := c.engines[n-1]
+		c.engines = c.engines[:n-1]
+		c.mu.Unlock()
+		return engine, nil
+	}
+	c.mu.Unlock()
+
+	start := time.Now()
+	engine, err := gapstone.New(gapstone.CS_ARCH_X86, gapstone.CS_MODE_64)
+	if err != nil {
+		return nil, fmt.Errorf("Capstone 엔진 생성 실패: %w", err)
+	}
+	if err := engine.SetOption(gapstone.CS_OPT_DETAIL, gapstone.CS_OPT_ON); err != nil { // JMP 추적 등에 필요
+		engine.Close()
+		return nil, fmt.Errorf("Capstone 옵션 설정 실패: %w", err)
+	}
+
+	c.mu.Lock()
+	c.all = append(c.all, &engine)
+	c.stats.Engines++
+	c.stats.EngineTime += time.Since(start)
+	c.mu.Unlock()
+	return &engine, nil
+}
+
+// releaseEngine : acquireEngine 으로 빌린 엔진 반환
+func (a *ELFAnalyzer) releaseEngine(engine *gapstone.Engine) {
+	c := &a.cache
+	c.mu.Lock()
+	c.engines = append(c.engines, engine)
+	c.mu.Unlock()
+}
+
+// closeEngines : 만든 모든 Capstone 엔진 정리
+func (a *ELFAnalyzer) closeEngines() {
+	c := &a.cache
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, engine := range c.all {
+		engine.Close()
+	}
+	c.all, c.engines = nil, nil
+}
+
+// disassemble : 캐시된 .text에서 [addr, addr+size) 범위를 역어셈
+// size가 0이면 4096바이트, .text 끝을 넘으면 끝까지로 자름
+func (a *ELFAnalyzer) disassemble(addr, size uint64) ([]gapstone.Instruction, error) {
+	if err := a.load(); err != nil {
+		return nil, err
+	}
+	c := &a.cache
+	if addr < c.textAdr || addr-c.textAdr >= uint64(len(c.text)) {
+		return nil, fmt.Errorf("심볼 주소가 .text 범위를 벗어남")
+	}
+	if size == 0 {
+		size = 4096 // 기본 크기
+	}
+	offset := addr - c.textAdr
+	end := offset + size
+	if end > uint64(len(c.text)) {
+		end = uint64(len(c.text))
+	}
+
+	engine, err := a.acquireEngine()
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	insns, err := engine.Disasm(c.text[offset:end], addr, 0)
+	elapsed := time.Since(start)
+	a.releaseEngine(engine)
+
+	c.mu.Lock()
+	c.stats.DisasmTime += elapsed
+	c.stats.Disasms++
+	c.stats.DisasmBytes += int(end - offset)
+	c.mu.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("Disasm 실패: %w", err)
+	}
+	return insns, nil
+}
+
+// Stats : 지금까지의 색인/역어셈 시간과 횟수
+func (a *ELFAnalyzer) Stats() Stats {
+	a.cache.mu.Lock()
+	defer a.cache.mu.Unlock()
+	return a.cache.stats
+}
@@ -0,0 +1,178 @@
+// pkg/analyzer/catalog.go
+package analyzer
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// bundledWrapperList : glibc가 export 하는 시스템 콜 래퍼 전체 목록 (cmd/gen-wrapper-list 로 생성)
+//
+//go:embed data/glibc_wrappers.txt
+var bundledWrapperList string
+
+// CatalogSource : 시스템 콜 래퍼 목록을 읽어 올 곳
+type CatalogSource string
+
+const (
+	SourceBundled CatalogSource = "bundled" // 내장된 glibc 래퍼 목록 (data/glibc_wrappers.txt)
+	SourceMan     CatalogSource = "man"     // 내장 목록 + 'man 2 syscalls' 파싱 결과 (manpages-dev 필요)
+	SourceFile    CatalogSource = "file"    // 한 줄에 이름 하나인 텍스트 파일 (# 이후는 주석)
+	SourceLibc    CatalogSource = "libc"    // libc export 함수 중 syscall 명령어가 있는 것 (ELFAnalyzer.SyscallFunctions 결과)
+)
+
+// CatalogOptions는 NewSyscallCatalog 설정
+type CatalogOptions struct {
+	Source   CatalogSource // 기본: SourceBundled
+	Path     string        // SourceFile 일 때 읽을 파일
+	Names    []string      // SourceLibc 일 때 사용할 목록 (SyscallFunctions 결과, 호출자가 libc build-id별로 캐시)
+	Fallback bool          // 목록을 읽지 못하면 SourceBundled 로 대체
+	Log      io.Writer     // 진행/경고 메시지 출력 (nil이면 출력하지 않음)
+}
+
+// SyscallCatalog는 "시스템 콜 래퍼로 볼 심볼 이름" 목록
+// 대상 ELF의 동적 심볼 중 이 목록에 있는 것만 libc에서 추적함
+type SyscallCatalog struct {
+	source CatalogSource
+	names  map[string]struct{}
+}
+
+// NewSyscallCatalog : opts.Source 에서 래퍼 목록을 읽어 카탈로그 생성
+// 패키지를 임포트하는 것만으로는 아무 일도 일어나지 않으며, 외부 명령(man)은 이 함수에서만 실행됨
+func NewSyscallCatalog(opts CatalogOptions) (*SyscallCatalog, error) {
+	logf := func(format string, args ...interface{}) {
+		if opts.Log != nil {
+			fmt.Fprintf(opts.Log, format, args...)
+		}
+	}
+
+	source := opts.Source
+	if source == "" {
+		source = SourceBundled
+	}
+
+	var names []string
+	var err error
+	switch source {
+	case SourceMan:
+		// man 페이지는 내장 목록을 보완하는 용도 (설치된 glibc보다 새로운 래퍼 등)
+		logf("Parsing 'man 2 syscalls' to get the list of syscalls...\n")
+		if names, err = parseMan(); err == nil {
+			names = append(names, bundledWrappers()...)
+		}
+	case SourceBundled:
+		names = bundledWrappers()
+	case SourceFile:
+		names, err = readCatalogFile(opts.Path)
+	case SourceLibc:
+		if names = opts.Names; len(names) == 0 {
+			err = fmt.Errorf("libc에서 syscall 명령어가 있는 함수를 찾지 못했습니다")
+		}
+	default:
+		return nil, fmt.Errorf("지원하지 않는 래퍼 목록 소스: %s (bundled, man, file, libc)", source)
+	}
+
+	if err != nil {
+		if !opts.Fallback {
+			return nil, fmt.Errorf("래퍼 목록(%s) 로드 실패: %w", source, err)
+		}
+		logf("경고: 래퍼 목록(%s)을 로드하지 못했습니다: %v\n", source, err)
+		logf("미리 정의된 정적 시스템 콜 목록을 사용합니다.\n")
+		source, names = SourceBundled, bundledWrappers()
+	}
+
+	c := &SyscallCatalog{source: source, names: make(map[string]struct{}, len(names))}
+	for _, name := range names {
+		c.names[name] = struct{}{}
+	}
+	logf("래퍼 목록 %d개 로드 (%s)\n", len(c.names), source)
+	return c, nil
+}
+
+// readCatalogFile : 한 줄에 이름 하나씩 적힌 래퍼 목록 파일 읽기
+func readCatalogFile(path string) ([]string, error) {
+	if path == "" {
+		return nil, fmt.Errorf("래퍼 목록 파일 경로가 지정되지 않았습니다")
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	names, err := parseWrapperList(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s 읽기 실패: %w", path, err)
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("%s 에 래퍼 이름이 없습니다", path)
+	}
+	return names, nil
+}
+
+// bundledWrappers : 내장된 glibc 래퍼 목록
+func bundledWrappers() []string {
+	names, _ := parseWrapperList(strings.NewReader(bundledWrapperList)) // 문자열 읽기는 실패하지 않음
+	return names
+}
+
+// parseWrapperList : 한 줄에 이름 하나인 목록 파싱 (# 이후는 주석)
+func parseWrapperList(r io.Reader) ([]string, error) {
+	var names []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		if name := strings.TrimSpace(line); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names, scanner.Err()
+}
+
+// Source : 실제로 목록을 읽어 온 곳 (Fallback 이 일어났으면 SourceBundled)
+func (c *SyscallCatalog) Source() CatalogSource {
+	return c.source
+}
+
+// Len : 목록의 래퍼 수
+func (c *SyscallCatalog) Len() int {
+	return len(c.names)
+}
+
+// Contains : 이름이 래퍼 목록에 있는지 확인
+func (c *SyscallCatalog) Contains(name string) bool {
+	_, ok := c.names[name]
+	return ok
+}
+
+// Names : 래퍼 목록 (알파벳순)
+func (c *SyscallCatalog) Names() []string {
+	names := make([]string, 0, len(c.names))
+	for name := range c.names {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Filter : 동적 심볼목록을 받아 그 중 시스템 콜 래퍼 함수만 필터링하여 반환
+func (c *SyscallCatalog) Filter(symbols []string) []string {
+	// 결과를 담을 슬라이스
+	foundSyscalls := make([]string, 0)
+
+	for _, symbol := range symbols {
+		// 현재 심볼이 래퍼 목록 Set에 있는지 확인 (O(1) 성능)
+		if c.Contains(symbol) {
+			foundSyscalls = append(foundSyscalls, symbol)
+		}
+	}
+	return foundSyscalls
+}
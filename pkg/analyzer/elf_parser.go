@@ -3,18 +3,34 @@ package analyzer
 
 import (
 	"bytes"
+	"context"
 	"debug/elf"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"ips_bpf/static-analyzer/pkg/asmanalysis"
+	"os"
 
 	//"io"
 	"github.com/knightsc/gapstone" //디스어셈블 라이브러리
 )
 
+// asmChunkInsns : ExtractAsmCode 가 한 번에 역어셈할 명령어 수 (취소 확인 간격)
+const asmChunkInsns = 65536
+
+// FindKernelSyscallPatterns 가 심볼 본문을 추적할 수 없을 때 반환하는 오류 (errors.Is 로 구분)
+var (
+	ErrSymbolNotFound = errors.New("심볼을 찾을 수 없음")
+	ErrIFunc          = errors.New("IFUNC 심볼 (실제 구현은 실행 시 리졸버가 선택)")
+	ErrPLTOnly        = errors.New("정의되지 않은 심볼 (다른 라이브러리에서 가져와 PLT로만 호출)")
+)
+
 // ELFAnalyzer는 파싱된 ELF 파일 정보를 담는 구조체
 type ELFAnalyzer struct {
 	elfFile *elf.File
+	path    string
+	cache   elfCache  // 심볼 색인, .text 데이터, Capstone 엔진 (cache.go)
+	funcs   funcIndex // 주소 -> 함수 이름 (functions.go)
 }
 
 // New : ELFAnalyzer 구조체 생성
@@ -23,11 +39,12 @@ func New(filePath string) (*ELFAnalyzer, error) {
 	if err != nil {
 		return nil, fmt.Errorf("ELF 파일을 여는 데 실패했습니다: %w", err)
 	}
-	return &ELFAnalyzer{elfFile: elfFile}, nil
+	return &ELFAnalyzer{elfFile: elfFile, path: filePath}, nil
 }
 
 // Close :  ELF 파일을 닫음. defer와 함께 사용.
 func (a *ELFAnalyzer) Close() {
+	a.closeEngines()
 	a.elfFile.Close()
 }
 
@@ -133,44 +150,43 @@ func (a *ELFAnalyzer) Section(name string) *elf.Section {
 }
 
 // ExtractAsmCode : .text 섹션의 기계어를 어셈블리 코드로 바꾸고 시작 주소 추출
-func (a *ELFAnalyzer) ExtractAsmCode() ([]gapstone.Instruction, uint64, error) {
-	textSect := a.Section(".text")
-	if textSect == nil {
-		return nil, 0, fmt.Errorf(".text 섹션을 찾을 수 없습니다. (섹션이 스트립되었을 수 있습니다)")
-	}
-
-	startAddr := textSect.Addr   // 섹션의 가상 주소(Virtual Address) 추출
-	data, err := textSect.Data() // 섹션의 실제 데이터 추출
-	if err != nil {
-		return nil, 0, fmt.Errorf(".text 섹션 데이터 읽기 실패: %v", err)
-	}
-
-	//gapstone 버전설정
-	engine, err := gapstone.New(
-		gapstone.CS_ARCH_X86,
-		gapstone.CS_MODE_64,
-	)
-	fmt.Println("ARCH_X86_64 , MODE_64")
-
-	// 디테일 옵션 활성화
-	err = engine.SetOption(gapstone.CS_OPT_DETAIL, gapstone.CS_OPT_ON)
-	if err != nil {
-		return nil, 0, fmt.Errorf("Capstone 옵션 설정 실패: %w", err)
+// .text 전체는 크므로 asmChunkInsns 개 명령어 단위로 나누어 역어셈하며, 그 사이마다 ctx 취소를 확인
+// 취소되면 그때까지 역어셈한 명령어와 ctx.Err()를 반환
+func (a *ELFAnalyzer) ExtractAsmCode(ctx context.Context) ([]gapstone.Instruction, uint64, error) {
+	// 섹션의 가상 주소(Virtual Address)와 실제 데이터는 처음 한 번만 읽어 둔 것을 사용
+	if err := a.load(); err != nil {
+		return nil, 0, err
 	}
+	startAddr, data := a.cache.textAdr, a.cache.text
 
+	//gapstone 버전설정 (디테일 옵션 활성화된 엔진 재사용)
+	engine, err := a.acquireEngine()
 	if err != nil {
-		return nil, 0, fmt.Errorf("Capstone 엔진 생성 실패: %w", err)
+		return nil, 0, err
 	}
-	defer engine.Close()
+	defer a.releaseEngine(engine)
+	fmt.Fprintln(os.Stderr, "ARCH_X86_64 , MODE_64")
 
 	maj, min := engine.Version()
-	fmt.Printf("Capstone 버전: %d.%d\n", maj, min)
+	fmt.Fprintf(os.Stderr, "Capstone 버전: %d.%d\n", maj, min)
 
-	insns, err := engine.Disasm(data, startAddr, 0) //gapstone를 이용한 디스어셈블
+	var insns []gapstone.Instruction
+	for offset := uint64(0); offset < uint64(len(data)); {
+		if err := ctx.Err(); err != nil {
+			return insns, startAddr, err
+		}
+		chunk, err := engine.Disasm(data[offset:], startAddr+offset, asmChunkInsns) //gapstone를 이용한 디스어셈블
 
-	if err != nil {
-		// Disasm 실패 시 오류 반환
-		return nil, 0, fmt.Errorf("Disasm 실패: %w", err)
+		if err != nil {
+			// Disasm 실패 시 오류 반환
+			return nil, 0, fmt.Errorf("Disasm 실패: %w", err)
+		}
+		if len(chunk) == 0 {
+			break // 해석할 수 없는 바이트에서 멈춤 (한 번에 역어셈할 때와 같은 동작)
+		}
+		insns = append(insns, chunk...)
+		last := chunk[len(chunk)-1]
+		offset = uint64(last.Address) + uint64(last.Size) - startAddr
 	}
 	return insns, startAddr, nil
 }
@@ -178,71 +194,62 @@ func (a *ELFAnalyzer) ExtractAsmCode() ([]gapstone.Instruction, uint64, error) {
 // FindKernelSyscallPatterns는 libc.so.6와 같은 라이브러리 파일 내에서
 // 특정 심볼 이름(예: "open")을 인자로 받아, 해당 함수가 호출하는
 // 모든 커널 시스템 콜 패턴을 반환합니다.
-func (a *ELFAnalyzer) FindKernelSyscallPatterns(symbolName string) ([]asmanalysis.SyscallInfo, error) {
-	// 1. libc.so.6의 동적 심볼 테이블에서 symbolName을 찾습니다.
-	symbols, err := a.elfFile.DynamicSymbols()
+// ctx가 이미 취소되었으면 역어셈하지 않고 ctx.Err()를 반환합니다.
+func (a *ELFAnalyzer) FindKernelSyscallPatterns(ctx context.Context, symbolName string) ([]asmanalysis.SyscallInfo, error) {
+	insns, err := a.symbolBody(ctx, symbolName)
 	if err != nil {
-		return nil, fmt.Errorf("동적 심볼 읽기 실패: %w", err)
+		return nil, err
 	}
 
-	// libsoc.6 내에서 symbolName과 일치하는 심볼을 찾음
-	var targetSymbol *elf.Symbol
-	for i, sym := range symbols {
-		if sym.Name == symbolName {
-			targetSymbol = &symbols[i]
-			break
-		}
-	}
-	if targetSymbol == nil {
-		// [난관 1: 심볼 매핑]
-		// "open" 심볼이 없고 "__open"만 있을 수 있습니다.
-		// 여기에 "open" -> "__open"으로 다시 검색하는 예외 처리 로직을 추가할 수 있습니다.
-		// (예: if strings.HasPrefix(symbolName, "__") ... else ... FindKernelSyscallPatterns("__" + symbolName))
-		return nil, fmt.Errorf("'%s' 심볼을 찾을 수 없음", symbolName)
-	}
+	// 4.어셈블리 트레이서
+	return asmanalysis.FindAllSyscalls(ctx, insns)
+}
 
-	// 2. 심볼의 주소(Value)와 크기(Size)를 이용해 .text 섹션에서 코드 추출
-	textSect := a.Section(".text")
-	// ... (오프셋 및 크기 계산 로직은 V1 계획서와 동일) ...
-	// (offset, size, data, code 추출...)
-	offset := targetSymbol.Value - textSect.Addr
-	size := targetSymbol.Size
-	if size == 0 {
-		size = 4096 // 기본 크기
-	}
-	data, err := textSect.Data()
+// DirectCallees : 심볼 본문이 직접 jmp/call 하는 다른 동적 심볼 이름 (등장 순, 중복 제거)
+// export 되지 않은 내부 함수는 크기를 알 수 없으므로 포함하지 않음
+func (a *ELFAnalyzer) DirectCallees(ctx context.Context, symbolName string) ([]string, error) {
+	insns, err := a.symbolBody(ctx, symbolName)
 	if err != nil {
-		return nil, fmt.Errorf(".text 데이터 읽기 실패: %w", err)
+		return nil, err
 	}
-	if offset >= uint64(len(data)) {
-		return nil, fmt.Errorf("심볼 주소가 .text 범위를 벗어남")
-	}
-	end := offset + size
-	if end > uint64(len(data)) {
-		end = uint64(len(data))
-	}
-	code := data[offset:end]
 
-	// 3. Gapstone(Capstone)으로 추출된 코드 역어셈블
-	engine, err := gapstone.New(gapstone.CS_ARCH_X86, gapstone.CS_MODE_64)
-	if err != nil {
-		return nil, fmt.Errorf("Capstone 엔진 생성 실패: %w", err)
+	var callees []string
+	for _, target := range asmanalysis.DirectBranchTargets(insns) {
+		if name, ok := a.SymbolAt(target); ok && name != symbolName {
+			callees = append(callees, name)
+		}
 	}
-	defer engine.Close()
-	engine.SetOption(gapstone.CS_OPT_DETAIL, gapstone.CS_OPT_ON) // JMP 추적 등에 필요
+	return callees, nil
+}
 
-	insns, err := engine.Disasm(code, targetSymbol.Value, 0)
-	if err != nil {
-		return nil, fmt.Errorf("Disasm 실패: %w", err)
+// symbolBody : 동적 심볼 하나의 본문을 역어셈
+// 심볼이 없거나(ErrSymbolNotFound), 정의되지 않았거나(ErrPLTOnly), IFUNC(ErrIFunc)이면 오류
+func (a *ELFAnalyzer) symbolBody(ctx context.Context, symbolName string) ([]gapstone.Instruction, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
 
-	// [난관 1: JMP 추적 심화]
-	// V1 계획서의 JMP 추적을 여기에 구현할 수 있습니다.
-	// if insns[0].Mnemonic == "jmp" && insns[0].X86.Operands[0].Type == gapstone.X86_OP_IMM {
-	//    jmpTargetAddr := insns[0].X86.Operands[0].Imm
-	//    // jmpTargetAddr를 기준으로 다시 Disasm... (복잡도 증가)
-	// }
+	// 1. libc.so.6의 동적 심볼 색인에서 symbolName을 찾습니다. (색인은 처음 한 번만 생성)
+	if err := a.load(); err != nil {
+		return nil, err
+	}
+	targetSymbol, ok := a.LookupSymbol(symbolName)
+	if !ok {
+		// [난관 1: 심볼 매핑]
+		// "open" 심볼이 없고 "__open"만 있을 수 있습니다.
+		// 여기에 "open" -> "__open"으로 다시 검색하는 예외 처리 로직을 추가할 수 있습니다.
+		// (예: if strings.HasPrefix(symbolName, "__") ... else ... FindKernelSyscallPatterns("__" + symbolName))
+		return nil, fmt.Errorf("'%s': %w", symbolName, ErrSymbolNotFound)
+	}
+	// 정의되지 않은 심볼은 본문이 없고, IFUNC 심볼의 본문은 구현을 고르는 리졸버이므로 추적하지 않음
+	switch {
+	case targetSymbol.Section == elf.SHN_UNDEF:
+		return nil, fmt.Errorf("'%s': %w", symbolName, ErrPLTOnly)
+	case elf.ST_TYPE(targetSymbol.Info) == elf.STT_GNU_IFUNC:
+		return nil, fmt.Errorf("'%s' (리졸버 0x%x): %w", symbolName, targetSymbol.Value, ErrIFunc)
+	}
 
-	// 4.어셈블리 트레이서
-	return asmanalysis.FindAllSyscalls(insns)
+	// 2-3. 심볼의 주소(Value)와 크기(Size)로 캐시된 .text에서 코드를 잘라 재사용 중인 Capstone 엔진으로 역어셈블
+	// (직접 jmp/call 은 DirectCallees 로 한 단계만 따라감)
+	return a.disassemble(targetSymbol.Value, targetSymbol.Size)
 }
@@ -0,0 +1,230 @@
+// pkg/analyzer/functions.go
+package analyzer
+
+import (
+	"debug/elf"
+	"errors"
+	"fmt"
+	"ips_bpf/static-analyzer/pkg/asmanalysis"
+	"sort"
+	"sync"
+)
+
+// funcRange는 함수 심볼 하나의 주소 범위
+type funcRange struct {
+	name  string
+	start uint64
+	size  uint64 // 0이면 크기를 모름 (다음 함수 시작 전까지로 봄)
+}
+
+// funcIndex : 함수 심볼 색인 (FunctionAt, FunctionStarts, EntryPoints 공용)
+type funcIndex struct {
+	once  sync.Once
+	funcs []funcRange // 시작 주소 순
+	exact bool        // .symtab 으로 만듦 (false면 동적 심볼만 있어 가장 가까운 export 함수로 추정)
+}
+
+// loadFuncs : 함수 심볼 색인을 처음 사용할 때 한 번만 만듦
+func (a *ELFAnalyzer) loadFuncs() *funcIndex {
+	idx := &a.funcs
+	idx.once.Do(func() {
+		symbols, err := a.elfFile.Symbols()
+		idx.exact = err == nil
+		if err != nil {
+			if symbols, err = a.elfFile.DynamicSymbols(); err != nil {
+				return
+			}
+		}
+		for _, sym := range symbols {
+			if elf.ST_TYPE(sym.Info) != elf.STT_FUNC || sym.Section == elf.SHN_UNDEF || sym.Value == 0 {
+				continue
+			}
+			idx.funcs = append(idx.funcs, funcRange{name: sym.Name, start: sym.Value, size: sym.Size})
+		}
+		sort.SliceStable(idx.funcs, func(i, j int) bool { return idx.funcs[i].start < idx.funcs[j].start })
+	})
+	return idx
+}
+
+// FunctionAt : 주소가 속한 함수 이름
+// .symtab 이 있으면 그 범위 안의 함수, 스트립된 바이너리는 주소 앞의 가장 가까운 동적 심볼 함수를 "이름+0x오프셋" 으로 반환
+// 찾지 못하면 ""
+func (a *ELFAnalyzer) FunctionAt(addr uint64) string {
+	idx := a.loadFuncs()
+
+	// addr 이하에서 시작하는 마지막 함수
+	i := sort.Search(len(idx.funcs), func(i int) bool { return idx.funcs[i].start > addr }) - 1
+	if i < 0 {
+		return ""
+	}
+	f := idx.funcs[i]
+	if f.size > 0 && addr < f.start+f.size {
+		return f.name
+	}
+	if idx.exact {
+		return "" // .symtab 의 어느 함수 범위에도 없음 (크기를 모르는 심볼 뒤의 코드 등)
+	}
+	return fmt.Sprintf("%s+0x%x", f.name, addr-f.start)
+}
+
+// FunctionStarts : 심볼로 알 수 있는 함수 시작 주소 (asmanalysis.BuildCallGraph 의 함수 경계)
+func (a *ELFAnalyzer) FunctionStarts() []uint64 {
+	idx := a.loadFuncs()
+	starts := make([]uint64, 0, len(idx.funcs))
+	for _, f := range idx.funcs {
+		starts = append(starts, f.start)
+	}
+	return starts
+}
+
+// rootSymbols : 이름으로 찾는 도달 가능성 분석 시작점 (.symtab 이 있을 때)
+var rootSymbols = []string{"main", "_start"}
+
+// EntryPoints : 호출 그래프 도달 가능성 분석의 시작점
+// e_entry, 생성자(DT_INIT, .preinit_array, .init_array), 소멸자(DT_FINI, .fini_array), export 된 함수,
+// 데이터에 저장된 코드 주소(dataCodePointers), main/_start 심볼
+func (a *ELFAnalyzer) EntryPoints() (asmanalysis.EntryPoints, error) {
+	roots := asmanalysis.EntryPoints{Entry: a.elfFile.Entry}
+	for _, f := range a.loadFuncs().funcs {
+		for _, name := range rootSymbols {
+			if f.name == name {
+				roots.Symbols = append(roots.Symbols, f.start)
+			}
+		}
+	}
+
+	for _, group := range []struct {
+		dst      *[]uint64
+		tag      elf.DynTag
+		sections []string
+	}{
+		{&roots.Init, elf.DT_INIT, []string{".preinit_array", ".init_array"}},
+		{&roots.Fini, elf.DT_FINI, []string{".fini_array"}},
+	} {
+		if values, err := a.elfFile.DynValue(group.tag); err == nil {
+			*group.dst = append(*group.dst, values...) // 정적 바이너리 등 .dynamic 이 없으면 건너뜀
+		}
+		for _, name := range group.sections {
+			ptrs, err := a.arrayPointers(name)
+			if err != nil {
+				return roots, err
+			}
+			*group.dst = append(*group.dst, ptrs...)
+		}
+	}
+
+	symbols, err := a.elfFile.DynamicSymbols()
+	if err != nil && !errors.Is(err, elf.ErrNoSymbols) {
+		return roots, fmt.Errorf("동적 심볼 읽기 실패: %w", err)
+	}
+	for _, sym := range symbols {
+		if elf.ST_TYPE(sym.Info) == elf.STT_FUNC && sym.Section != elf.SHN_UNDEF && sym.Value != 0 {
+			roots.Exports = append(roots.Exports, sym.Value)
+		}
+	}
+
+	if roots.Data, err = a.dataCodePointers(symbols); err != nil {
+		return roots, err
+	}
+	return roots, nil
+}
+
+// dataCodePointers : 데이터에 저장된 실행 섹션 주소 (간접 호출로만 불리는 함수의 시작점)
+//   - R_X86_64_RELATIVE/IRELATIVE 재배치의 Addend (PIE 의 .data.rel.ro vtable, 콜백 표 등)
+//   - 정의된 심볼에 대한 R_X86_64_64 재배치의 심볼 값 + Addend
+//   - non-PIE(ET_EXEC)는 재배치 없이 절대 주소가 저장되므로, 데이터 섹션의 8바이트 정렬 값 중 실행 섹션을 가리키는 값
+func (a *ELFAnalyzer) dataCodePointers(symbols []elf.Symbol) ([]uint64, error) {
+	isCode := func(addr uint64) bool {
+		for _, sect := range a.elfFile.Sections {
+			if sect.Flags&elf.SHF_EXECINSTR != 0 && addr >= sect.Addr && addr < sect.Addr+sect.Size {
+				return true
+			}
+		}
+		return false
+	}
+
+	seen := make(map[uint64]struct{})
+	var ptrs []uint64
+	add := func(addr uint64) {
+		if _, ok := seen[addr]; !ok && isCode(addr) {
+			seen[addr] = struct{}{}
+			ptrs = append(ptrs, addr)
+		}
+	}
+
+	for _, name := range []string{".rela.dyn", ".rela.plt"} {
+		entries, err := a.relaEntries(name)
+		if err != nil {
+			return nil, err
+		}
+		for _, rela := range entries {
+			switch elf.R_X86_64(elf.R_TYPE64(rela.Info)) {
+			case elf.R_X86_64_RELATIVE, elf.R_X86_64_IRELATIVE:
+				add(uint64(rela.Addend))
+			case elf.R_X86_64_64:
+				symIndex := int(elf.R_SYM64(rela.Info))
+				if symIndex >= 1 && symIndex <= len(symbols) && symbols[symIndex-1].Section != elf.SHN_UNDEF {
+					add(symbols[symIndex-1].Value + uint64(rela.Addend))
+				}
+			}
+		}
+	}
+
+	if a.elfFile.Type == elf.ET_EXEC {
+		order := a.elfFile.ByteOrder
+		for _, sect := range a.elfFile.Sections {
+			if sect.Type != elf.SHT_PROGBITS || sect.Flags&elf.SHF_ALLOC == 0 || sect.Flags&elf.SHF_EXECINSTR != 0 {
+				continue
+			}
+			data, err := sect.Data()
+			if err != nil {
+				return nil, fmt.Errorf("%s 섹션 읽기 실패: %w", sect.Name, err)
+			}
+			for off := (8 - int(sect.Addr%8)) % 8; off+8 <= len(data); off += 8 {
+				add(order.Uint64(data[off:]))
+			}
+		}
+	}
+
+	sort.Slice(ptrs, func(i, j int) bool { return ptrs[i] < ptrs[j] })
+	return ptrs, nil
+}
+
+// arrayPointers : 함수 포인터 배열 섹션(.init_array 등)의 항목
+// PIE 는 섹션 내용이 0이고 R_X86_64_RELATIVE 재배치의 Addend 가 실제 주소이므로 재배치를 함께 읽음
+func (a *ELFAnalyzer) arrayPointers(name string) ([]uint64, error) {
+	sect := a.Section(name)
+	if sect == nil || sect.Type == elf.SHT_NOBITS {
+		return nil, nil
+	}
+	data, err := sect.Data()
+	if err != nil {
+		return nil, fmt.Errorf("%s 섹션 읽기 실패: %w", name, err)
+	}
+
+	order := a.elfFile.ByteOrder
+	slots := make(map[uint64]uint64) // 항목 주소 -> 함수 주소
+	for off := 0; off+8 <= len(data); off += 8 {
+		if ptr := order.Uint64(data[off:]); ptr != 0 && ptr != ^uint64(0) {
+			slots[sect.Addr+uint64(off)] = ptr
+		}
+	}
+
+	entries, err := a.relaEntries(".rela.dyn")
+	if err != nil {
+		return nil, err
+	}
+	for _, rela := range entries {
+		if elf.R_X86_64(elf.R_TYPE64(rela.Info)) != elf.R_X86_64_RELATIVE || rela.Off < sect.Addr || rela.Off >= sect.Addr+sect.Size {
+			continue
+		}
+		slots[rela.Off] = uint64(rela.Addend)
+	}
+
+	ptrs := make([]uint64, 0, len(slots))
+	for _, ptr := range slots {
+		ptrs = append(ptrs, ptr)
+	}
+	sort.Slice(ptrs, func(i, j int) bool { return ptrs[i] < ptrs[j] })
+	return ptrs, nil
+}
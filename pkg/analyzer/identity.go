@@ -0,0 +1,86 @@
+// pkg/analyzer/identity.go
+package analyzer
+
+import (
+	"crypto/sha256"
+	"debug/elf"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+)
+
+// NT_GNU_BUILD_ID 노트 타입 (elf.h)
+const ntGNUBuildID = 3
+
+// Path : 분석 중인 ELF 파일 경로
+func (a *ELFAnalyzer) Path() string {
+	return a.path
+}
+
+// SHA256 : 파일 전체 내용의 sha256 (hex), 결과 저장 키로 사용
+func (a *ELFAnalyzer) SHA256() (string, error) {
+	f, err := os.Open(a.path)
+	if err != nil {
+		return "", fmt.Errorf("파일 열기 실패: %w", err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("sha256 계산 실패: %w", err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// BuildID : .note.gnu.build-id 의 NT_GNU_BUILD_ID 값 (hex), 링크 시 --build-id 가 없었다면 빈 문자열
+func (a *ELFAnalyzer) BuildID() (string, error) {
+	for _, sect := range a.elfFile.Sections {
+		if sect.Type != elf.SHT_NOTE {
+			continue
+		}
+		data, err := sect.Data()
+		if err != nil {
+			return "", fmt.Errorf("%s 섹션 읽기 실패: %w", sect.Name, err)
+		}
+		if id, ok := a.findGNUBuildID(data); ok {
+			return id, nil
+		}
+	}
+	return "", nil
+}
+
+// findGNUBuildID : 노트 섹션 데이터에서 이름이 "GNU"인 NT_GNU_BUILD_ID 노트를 찾음
+// 노트 형식: namesz(4) descsz(4) type(4) name(4바이트 정렬) desc(4바이트 정렬)
+func (a *ELFAnalyzer) findGNUBuildID(data []byte) (string, bool) {
+	order := a.elfFile.ByteOrder
+	align4 := func(n uint32) uint32 { return (n + 3) &^ 3 }
+
+	for len(data) >= 12 {
+		namesz := order.Uint32(data[0:4])
+		descsz := order.Uint32(data[4:8])
+		noteType := order.Uint32(data[8:12])
+		data = data[12:]
+
+		nameEnd := uint64(align4(namesz))
+		descEnd := nameEnd + uint64(align4(descsz))
+		if descEnd > uint64(len(data)) || uint64(namesz) > nameEnd {
+			return "", false // 손상된 노트
+		}
+
+		name := string(data[:namesz])
+		if noteType == ntGNUBuildID && name == "GNU\x00" {
+			return hex.EncodeToString(data[nameEnd : nameEnd+uint64(descsz)]), true
+		}
+		data = data[descEnd:]
+	}
+	return "", false
+}
+
+// Arch : ELF 헤더의 머신 타입 ("x86_64", 그 외는 debug/elf 이름 예: "EM_AARCH64")
+func (a *ELFAnalyzer) Arch() string {
+	if a.elfFile.Machine == elf.EM_X86_64 {
+		return "x86_64"
+	}
+	return a.elfFile.Machine.String()
+}
@@ -0,0 +1,74 @@
+// pkg/analyzer/libc_catalog.go
+package analyzer
+
+import (
+	"context"
+	"debug/elf"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+// ExportedFuncs : 정의된(export) FUNC 동적 심볼 이름 (알파벳순)
+func (a *ELFAnalyzer) ExportedFuncs() ([]string, error) {
+	if err := a.load(); err != nil {
+		return nil, err
+	}
+	var names []string
+	for name, sym := range a.cache.byName {
+		if sym.Section == elf.SHN_UNDEF || elf.ST_TYPE(sym.Info) != elf.STT_FUNC {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// SyscallFunctions : export FUNC 심볼 중 함수 본문에 'syscall' 명령어가 있는 것 (SourceLibc 래퍼 목록)
+// 심볼마다 FindKernelSyscallPatterns 로 역어셈하므로 concurrency 개(0 이하면 CPU 수) 고루틴이 나누어 수행
+// ctx가 취소되면 부분 목록 없이 ctx.Err()를 반환
+func (a *ELFAnalyzer) SyscallFunctions(ctx context.Context, concurrency int) ([]string, error) {
+	funcs, err := a.ExportedFuncs()
+	if err != nil {
+		return nil, err
+	}
+	if concurrency <= 0 {
+		concurrency = runtime.NumCPU()
+	}
+
+	found := make([]bool, len(funcs))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				patterns, err := a.FindKernelSyscallPatterns(ctx, funcs[i])
+				found[i] = err == nil && len(patterns) > 0
+			}
+		}()
+	}
+dispatch:
+	for i := range funcs {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for i, name := range funcs {
+		if found[i] {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
@@ -0,0 +1,381 @@
+// pkg/analyzer/plt.go
+package analyzer
+
+import (
+	"context"
+	"debug/elf"
+	"fmt"
+	"ips_bpf/static-analyzer/pkg/asmanalysis"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/knightsc/gapstone"
+)
+
+// pltSections : 가져온(import) 함수로 가는 PLT 항목이 있는 섹션
+// .plt (지연 바인딩), .plt.sec (IBT/CET로 분리된 항목), .plt.got (GLOB_DAT 재배치만 있는 함수)
+var pltSections = []string{".plt", ".plt.sec", ".plt.got"}
+
+// importReloc는 동적 심볼을 가리키는 재배치 하나 (.rela.plt, .rela.dyn)
+type importReloc struct {
+	slot      uint64       // 재배치가 값을 쓰는 주소 (GOT 엔트리, 데이터의 함수 포인터 자리 등)
+	symbol    string       // 재배치 대상 심볼 이름
+	typ       elf.R_X86_64 // 재배치 종류
+	undefined bool         // 대상 심볼이 이 바이너리에 정의되지 않음 (가져온 심볼)
+}
+
+// dynamicRelocs : .rela.plt, .rela.dyn 에서 심볼을 가리키는 재배치 (RELATIVE 처럼 심볼이 없는 재배치 제외)
+func (a *ELFAnalyzer) dynamicRelocs() ([]importReloc, error) {
+	symbols, err := a.elfFile.DynamicSymbols()
+	if err != nil {
+		return nil, fmt.Errorf("동적 심볼 읽기 실패: %w", err)
+	}
+
+	var relocs []importReloc
+	for _, name := range []string{".rela.plt", ".rela.dyn"} {
+		entries, err := a.relaEntries(name)
+		if err != nil {
+			return nil, err
+		}
+		for _, rela := range entries {
+			symIndex := int(elf.R_SYM64(rela.Info))
+			// elf.File.DynamicSymbols()는 Index 0(UNDEF)을 뺀 배열이므로 i-1
+			if symIndex < 1 || symIndex > len(symbols) {
+				continue
+			}
+			sym := symbols[symIndex-1]
+			relocs = append(relocs, importReloc{
+				slot:      rela.Off,
+				symbol:    sym.Name,
+				typ:       elf.R_X86_64(elf.R_TYPE64(rela.Info)),
+				undefined: sym.Section == elf.SHN_UNDEF,
+			})
+		}
+	}
+	return relocs, nil
+}
+
+// relaEntries : 재배치 섹션(.rela.dyn 등)의 엔트리 (섹션이 없으면 nil)
+func (a *ELFAnalyzer) relaEntries(name string) ([]elf.Rela64, error) {
+	sect := a.Section(name)
+	if sect == nil {
+		return nil, nil
+	}
+	data, err := sect.Data()
+	if err != nil {
+		return nil, fmt.Errorf("%s 섹션 읽기 실패: %w", name, err)
+	}
+
+	const relaSize = 24 // 64비트 Rela 엔트리 크기 (Off: 8, Info: 8, Addend: 8 바이트)
+	order := a.elfFile.ByteOrder
+	entries := make([]elf.Rela64, 0, len(data)/relaSize)
+	for off := 0; off+relaSize <= len(data); off += relaSize {
+		entries = append(entries, elf.Rela64{
+			Off:    order.Uint64(data[off:]),
+			Info:   order.Uint64(data[off+8:]),
+			Addend: int64(order.Uint64(data[off+16:])),
+		})
+	}
+	return entries, nil
+}
+
+// GOTImports : GOT 엔트리 주소 -> 가져온 심볼 이름 (.rela.plt 의 JUMP_SLOT, .rela.dyn 의 GLOB_DAT 재배치)
+func (a *ELFAnalyzer) GOTImports() (map[uint64]string, error) {
+	relocs, err := a.dynamicRelocs()
+	if err != nil {
+		return nil, err
+	}
+	return gotSlots(relocs), nil
+}
+
+func gotSlots(relocs []importReloc) map[uint64]string {
+	got := make(map[uint64]string)
+	for _, r := range relocs {
+		if r.typ == elf.R_X86_64_JMP_SLOT || r.typ == elf.R_X86_64_GLOB_DAT {
+			got[r.slot] = r.symbol
+		}
+	}
+	return got
+}
+
+// PLTStubs : PLT 항목 시작 주소 -> 가져온 심볼 이름
+// 각 PLT 섹션을 역어셈하여 "jmp qword ptr [rip + X]" 가 가리키는 GOT 엔트리를 GOTImports 로 이름에 연결
+// (IBT 항목은 바로 앞의 endbr64 가 항목의 시작)
+func (a *ELFAnalyzer) PLTStubs() (map[uint64]string, error) {
+	got, err := a.GOTImports()
+	if err != nil {
+		return nil, err
+	}
+	stubs, _, err := a.pltStubs(got)
+	return stubs, err
+}
+
+// pltStubs : PLTStubs 와 같고, PLT 항목이 가리키는 것으로 확인된 GOT 엔트리 주소도 함께 반환
+func (a *ELFAnalyzer) pltStubs(got map[uint64]string) (map[uint64]string, map[uint64]struct{}, error) {
+	stubs := make(map[uint64]string)
+	slots := make(map[uint64]struct{})
+	for _, name := range pltSections {
+		sect := a.Section(name)
+		if sect == nil {
+			continue
+		}
+		insns, err := a.disassembleSection(sect)
+		if err != nil {
+			return nil, nil, err
+		}
+		for i, insn := range insns {
+			gotAddr, ok := asmanalysis.RIPTarget(insn)
+			if !ok || !strings.HasSuffix(insn.Mnemonic, "jmp") {
+				continue
+			}
+			symbol, ok := got[gotAddr]
+			if !ok {
+				continue
+			}
+			start := uint64(insn.Address)
+			if i > 0 && insns[i-1].Mnemonic == "endbr64" {
+				start = uint64(insns[i-1].Address)
+			}
+			stubs[start] = symbol
+			slots[gotAddr] = struct{}{}
+		}
+	}
+	return stubs, slots, nil
+}
+
+// ImportTargets : 가져온 함수로 가는 호출이 가리킬 수 있는 주소 -> 심볼 이름
+// PLT 항목(call 0x...)과 GOT 엔트리(-fno-plt 의 call [rip + X])를 함께 담음
+func (a *ELFAnalyzer) ImportTargets() (map[uint64]string, error) {
+	relocs, err := a.dynamicRelocs()
+	if err != nil {
+		return nil, err
+	}
+	targets, _, err := a.importTargets(relocs)
+	return targets, err
+}
+
+func (a *ELFAnalyzer) importTargets(relocs []importReloc) (map[uint64]string, map[uint64]struct{}, error) {
+	got := gotSlots(relocs)
+	targets, stubSlots, err := a.pltStubs(got)
+	if err != nil {
+		return nil, nil, err
+	}
+	for addr, symbol := range got {
+		targets[addr] = symbol
+	}
+	return targets, stubSlots, nil
+}
+
+// ImportCall은 대상 바이너리 안의 가져온 함수 호출(또는 주소 참조) 하나
+type ImportCall struct {
+	asmanalysis.CallSite
+	Caller    string // 호출한 함수 (FunctionAt, 찾지 못하면 "", DataRef 면 재배치가 있는 섹션 이름)
+	Reachable bool   // 호출한 함수가 EntryPoints 에서 도달 가능 (시작점을 찾지 못하면 모두 true, DataRef 는 항상 true)
+	DataRef   bool   // 코드가 아닌 데이터에 주소가 저장됨 (R_X86_64_64 함수 포인터, 코드 스캔으로 확인할 수 없는 재배치)
+}
+
+// ImportCallSites : 대상 바이너리의 실행 섹션(.init, .text, .fini 등, codeInstructions)에서 가져온 함수 names 로 가는 호출 지점과 인자 레지스터 값
+// 호출한 함수가 진입점(e_entry, 생성자, 소멸자, export 함수)에서 도달 가능한지도 함께 표시
+// 데이터의 함수 포인터(R_X86_64_64 등)처럼 코드 스캔으로 확인할 수 없는 참조는 DataRef(도달 가능)로 덧붙이므로,
+// 호출 지점이 하나도 없으면 그 심볼을 가리키는 재배치를 모두 확인한 결과임
+// 반환값은 {가져온 심볼 이름: 코드 호출 지점 목록 (주소 순) + 데이터 참조}
+func (a *ELFAnalyzer) ImportCallSites(ctx context.Context, names map[string]struct{}) (map[string][]ImportCall, error) {
+	relocs, err := a.dynamicRelocs()
+	if err != nil {
+		return nil, err
+	}
+	imports, stubSlots, err := a.importTargets(relocs)
+	if err != nil {
+		return nil, err
+	}
+	targets := make(map[uint64]struct{})
+	for addr, symbol := range imports {
+		if _, ok := names[symbol]; ok {
+			targets[addr] = struct{}{}
+		}
+	}
+
+	bySymbol := make(map[string][]ImportCall)
+	if len(targets) > 0 {
+		insns, err := a.codeInstructions(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if len(insns) == 0 {
+			// 호출 지점이 없는 것과 구분 (모든 래퍼가 dead import 로 표시되지 않도록)
+			return nil, fmt.Errorf("실행 섹션에서 역어셈된 명령어가 없습니다")
+		}
+		sites, err := asmanalysis.FindCallSites(ctx, insns, targets)
+		if err != nil {
+			return nil, err
+		}
+
+		reachable, err := a.reachableFunctions(ctx, insns)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, site := range sites {
+			call := ImportCall{CallSite: site, Caller: a.FunctionAt(site.Address), Reachable: reachable == nil}
+			if reachable != nil {
+				call.Reachable = reachable(site.Address)
+			}
+			symbol := imports[site.Target]
+			bySymbol[symbol] = append(bySymbol[symbol], call)
+		}
+	}
+
+	for _, ref := range a.dataRefs(relocs, stubSlots) {
+		if _, ok := names[ref.symbol]; !ok {
+			continue
+		}
+		// 데이터에 저장된 주소는 어디서 호출될지 모르므로 도달 가능으로 봄
+		bySymbol[ref.symbol] = append(bySymbol[ref.symbol], ImportCall{
+			CallSite:  asmanalysis.CallSite{Address: ref.slot, AddressTaken: true},
+			Caller:    a.sectionName(ref.slot),
+			Reachable: true,
+			DataRef:   true,
+		})
+	}
+	return bySymbol, nil
+}
+
+// dataRefs : 코드 스캔(PLT 호출, GOT 참조)으로 확인할 수 없는 가져온 함수 참조
+//   - R_X86_64_64 등 JUMP_SLOT/GLOB_DAT 가 아닌 재배치 (테이블/구조체에 저장된 &close 같은 함수 포인터)
+//   - PLT 항목을 찾지 못한 JUMP_SLOT (알 수 없는 PLT 형식)
+//   - 값이 0이 아닌 정의되지 않은 FUNC 동적 심볼 (non-PIE 에서 주소를 쓰는 함수의 canonical PLT 주소)
+func (a *ELFAnalyzer) dataRefs(relocs []importReloc, stubSlots map[uint64]struct{}) []importReloc {
+	var refs []importReloc
+	for _, r := range relocs {
+		if !r.undefined {
+			continue
+		}
+		switch r.typ {
+		case elf.R_X86_64_GLOB_DAT:
+			continue // GOT 참조는 코드 스캔에서 확인
+		case elf.R_X86_64_JMP_SLOT:
+			if _, ok := stubSlots[r.slot]; ok {
+				continue
+			}
+		}
+		refs = append(refs, r)
+	}
+
+	symbols, err := a.elfFile.DynamicSymbols()
+	if err != nil {
+		return refs
+	}
+	for _, sym := range symbols {
+		if sym.Section == elf.SHN_UNDEF && elf.ST_TYPE(sym.Info) == elf.STT_FUNC && sym.Value != 0 {
+			refs = append(refs, importReloc{slot: sym.Value, symbol: sym.Name, undefined: true})
+		}
+	}
+	return refs
+}
+
+// sectionName : 주소가 속한 섹션 이름 (없으면 "")
+func (a *ELFAnalyzer) sectionName(addr uint64) string {
+	for _, sect := range a.elfFile.Sections {
+		if sect.Flags&elf.SHF_ALLOC != 0 && addr >= sect.Addr && addr < sect.Addr+sect.Size {
+			return sect.Name
+		}
+	}
+	return ""
+}
+
+// reachableFunctions : 주소가 진입점에서 도달 가능한 함수에 속하는지 확인하는 함수 (시작점이 없으면 nil)
+func (a *ELFAnalyzer) reachableFunctions(ctx context.Context, insns []gapstone.Instruction) (func(addr uint64) bool, error) {
+	entryPoints, err := a.EntryPoints()
+	if err != nil {
+		return nil, err
+	}
+	roots := entryPoints.All()
+	if len(roots) == 0 {
+		return nil, nil
+	}
+	graph, err := asmanalysis.BuildCallGraph(ctx, insns, append(a.FunctionStarts(), roots...))
+	if err != nil {
+		return nil, err
+	}
+	reachable := graph.Reachable(roots)
+	fmt.Fprintf(os.Stderr, "도달 가능성: 시작점 %d개 (init %d, fini %d, export %d, 데이터 포인터 %d), 함수 %d개 중 %d개 도달 가능\n",
+		len(roots), len(entryPoints.Init), len(entryPoints.Fini), len(entryPoints.Exports), len(entryPoints.Data), graph.Functions(), len(reachable))
+	return func(addr uint64) bool {
+		fn, ok := graph.FunctionOf(addr)
+		if !ok {
+			return true // 첫 함수보다 앞의 코드는 판단하지 않음
+		}
+		_, ok = reachable[fn]
+		return ok
+	}, nil
+}
+
+// codeInstructions : 호출 지점 탐색과 호출 그래프에 쓰는 모든 실행 섹션의 명령어 (주소 순)
+// .text 는 ExtractAsmCode 로, .init/.fini 등 나머지 SHF_EXECINSTR 섹션은 disassembleSection 으로 역어셈
+// PLT 섹션(pltSections, .iplt)은 가져온 함수로 가는 스텁일 뿐 호출 지점이 아니므로 제외
+func (a *ELFAnalyzer) codeInstructions(ctx context.Context) ([]gapstone.Instruction, error) {
+	type piece struct {
+		addr  uint64
+		insns []gapstone.Instruction
+	}
+	var pieces []piece
+	for _, sect := range a.elfFile.Sections {
+		if sect.Type != elf.SHT_PROGBITS || sect.Flags&elf.SHF_EXECINSTR == 0 || isPLTSection(sect.Name) {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		var insns []gapstone.Instruction
+		var err error
+		if sect.Name == ".text" {
+			insns, _, err = a.ExtractAsmCode(ctx)
+		} else {
+			insns, err = a.disassembleSection(sect)
+		}
+		if err != nil {
+			return nil, err
+		}
+		pieces = append(pieces, piece{sect.Addr, insns})
+	}
+	sort.Slice(pieces, func(i, j int) bool { return pieces[i].addr < pieces[j].addr })
+
+	var all []gapstone.Instruction
+	for _, p := range pieces {
+		all = append(all, p.insns...)
+	}
+	return all, nil
+}
+
+// isPLTSection : 가져온 함수로 가는 PLT 스텁만 있는 섹션인지 확인
+func isPLTSection(name string) bool {
+	if name == ".iplt" {
+		return true // 정적 바이너리의 IRELATIVE 스텁
+	}
+	for _, plt := range pltSections {
+		if name == plt {
+			return true
+		}
+	}
+	return false
+}
+
+// disassembleSection : .text 외의 작은 코드 섹션(PLT, .init, .fini 등) 전체를 역어셈
+func (a *ELFAnalyzer) disassembleSection(sect *elf.Section) ([]gapstone.Instruction, error) {
+	data, err := sect.Data()
+	if err != nil {
+		return nil, fmt.Errorf("%s 섹션 읽기 실패: %w", sect.Name, err)
+	}
+	engine, err := a.acquireEngine()
+	if err != nil {
+		return nil, err
+	}
+	defer a.releaseEngine(engine)
+
+	insns, err := engine.Disasm(data, sect.Addr, 0)
+	if err != nil {
+		return nil, fmt.Errorf("%s Disasm 실패: %w", sect.Name, err)
+	}
+	return insns, nil
+}
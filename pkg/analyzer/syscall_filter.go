@@ -11,47 +11,8 @@ import (
 	"strings"
 )
 
-// 시스템 호출 목록을 저장하기 위한 Set (map)
-var syscallSet = make(map[string]struct{})
-
-func init() {
-	//https://patorjk.com/software/taag/
-	//Big 글꼴 사용
-	fmt.Println()
-	fmt.Println(`                  %%@@@@@@@@%       %@@@@@@@@@&    `)
-	fmt.Println(`                @@@@@@@@@@@@@@@  %@@@@@@@@@@@@@@@    ______ _      ______    _____ _        _   _                             _                    `)
-	fmt.Println(`    @@@@@@@@@ %@@@@@@%@%@@@@@@@@@@@@@@%@%%@@@@@@@@  |  ____| |    |  ____|  / ____| |      | | (_)          /\               | |                   `)
-	fmt.Println(`@@@@@@@@@@@@%%@@@@@%         %@@@@@@@       %@@@@@  | |__  | |    | |__    | (___ | |_ __ _| |_ _  ___     /  \   _ __   __ _| |_   _ _______ _ __ `)
-	fmt.Println(`      @@@@@% %@@@@@   @@@@@@@@@@@@@@         @@@@@  |  __| | |    |  __|    \___ \| __/ _' | __| |/ __|   / /\ \ | '_ \ / _' | | | | |_  / _ \ '__|`)
-	fmt.Println(`             %@@@@@   %%%%@@@@@@@@@@%       @@@@@@  | |____| |____| |       ____) | || (_| | |_| | (__   / ____ \| | | | (_| | | |_| |/ /  __/ |   `)
-	fmt.Println(`             %@@@@@@%%%%%@@@@@%@@@@@@%%  @@@@@@@@   |______|______|_|      |_____/ \__\__,_|\__|_|\___| /_/    \_\_| |_|\__,_|_|\__, /___\___|_|   `)
-	fmt.Println(`              @@@@@@@@@@@@@@@@  @@@@@@@@@@@@@%@@                                                                                 __/ |             `)
-	fmt.Println(`                %@@@@@@@@%%       %@@@@@@@@@@%                                                                                  |___/              `)
-	fmt.Println()
-	fmt.Println(" [ ELF Static Analyzer Booting... ]")
-	fmt.Println()
-
-	// TODO 현재 man 2 syscalls 파싱하여 얻은 목록을 sys(#)와 tracepoint참고하여 생성래퍼함수 목록만들도록 리팩토링할 예정
-	// for 추가 가공 없이 바로 tracepoint 후킹코드 생성가능하도록
-	// 그러나 그냥 만들어진 목록에 대해 tracepoint 존재 여부 확인하는걸로. 뒤에서 체크한다
-
-	//proc/kallsyms에서 동적으로 시스템 콜 목록추출
-	syscalls, err := parseMan()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "경고: /proc/kallsyms에서 시스템 콜 목록을 동적으로 로드하지 못했습니다: %v\n", err)
-		fmt.Fprintln(os.Stderr, "미리 정의된 정적 시스템 콜 목록을 사용합니다.")
-		syscalls = getStaticSyscallList()
-	}
-
-	// 3. 최종적으로 얻은 시스템 콜 목록으로 Set생성
-	for _, s := range syscalls {
-		syscallSet[s] = struct{}{}
-	}
-}
-
+// parseMan : 'man 2 syscalls' 의 시스템 콜 표에서 x86_64에 해당하는 이름을 추출 (NewSyscallCatalog 의 man 소스)
 func parseMan() ([]string, error) {
-	fmt.Println("Parsing 'man 2 syscalls' to get the list of syscalls...")
-
 	// 'man' 명령어의 출력이 시스템 언어 설정에 영향을 받지 않도록 로케일을 'C' (영어)로 설정
 	cmd := exec.Command("man", "2", "syscalls")
 	cmd.Env = append(os.Environ(), "LC_ALL=C")
@@ -138,8 +99,8 @@ func parseMan() ([]string, error) {
 	}
 
 	if len(syscallSet) == 0 {
-		// man 페이지 파싱실패, 아래 getStatocSyscallList()목록 사용하게됨
-		fmt.Println("\n[WARNING] Could not parse any valid syscalls from man page.")
+		// man 페이지 파싱실패, NewSyscallCatalog 가 (opts.Fallback 이면) 내장 목록 bundledWrappers() 로 대체할 수 있도록 오류 반환
+		return nil, fmt.Errorf("could not parse any valid syscalls from man page")
 	}
 
 	// map의 키(시스템 콜 이름)를 슬라이스로 변환
@@ -150,44 +111,9 @@ func parseMan() ([]string, error) {
 
 	// 알파벳순 정렬
 	sort.Strings(syscalls)
-
-	fmt.Printf("Successfully parsed %d filtered syscalls from man page.\n", len(syscalls))
 	return syscalls, nil
 }
 
-func getStaticSyscallList() []string {
-	return []string{
-		"read", "write", "open", "close", "stat", "fstat", "lstat", "poll", "lseek", "mmap",
-		"mprotect", "munmap", "brk", "rt_sigaction", "rt_sigprocmask", "rt_sigreturn", "ioctl",
-		"pread64", "pwrite64", "readv", "writev", "access", "pipe", "select", "sched_yield",
-		"mremap", "msync", "mincore", "madvise", "shmget", "shmat", "shmctl", "dup", "dup2",
-		"pause", "nanosleep", "getitimer", "alarm", "setitimer", "getpid", "sendfile", "socket",
-		"connect", "accept", "sendto", "recvfrom", "sendmsg", "recvmsg", "shutdown", "bind",
-		"listen", "getsockname", "getpeername", "socketpair", "setsockopt", "getsockopt",
-		"clone", "fork", "vfork", "execve", "exit", "wait4", "kill", "uname", "semget", "semop",
-		"semctl", "shmdt", "msgget", "msgsnd", "msgrcv", "msgctl", "fcntl", "flock", "fsync",
-		"fdatasync", "truncate", "ftruncate", "getdents", "getcwd", "chdir", "fchdir", "rename",
-		"mkdir", "rmdir", "creat", "link", "unlink", "symlink", "readlink", "chmod", "fchmod",
-		"chown", "fchown", "lchown", "umask", "gettimeofday", "getrlimit", "getrusage", "sysinfo",
-		"times", "ptrace", "getuid", "syslog", "getgid", "setuid", "setgid", "geteuid", "getegid",
-		// 필요시 추가
-	}
-}
-
-// 동적 심볼목록을 받아 그 중 시스템 콜 래퍼 함수만 필터링하여 반환하는 함수
-func FilterSyscalls(symbols []string) []string {
-	// 결과를 담을 슬라이스
-	foundSyscalls := make([]string, 0)
-
-	for _, symbol := range symbols {
-		// 현재 심볼이 미리 정의된 시스템 콜 Set에 있는지 확인 (O(1) 성능)
-		if _, ok := syscallSet[symbol]; ok {
-			foundSyscalls = append(foundSyscalls, symbol)
-		}
-	}
-	return foundSyscalls
-}
-
 // GetKernelSyscallName은 커널 시스템 콜 번호를 이름으로 변환합니다.
 func GetKernelSyscallName(num int64) (string, bool) {
 	name, ok := kernelSyscallNameMap[num]
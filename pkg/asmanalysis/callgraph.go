@@ -0,0 +1,179 @@
+package asmanalysis
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/knightsc/gapstone"
+)
+
+// CallGraph는 명령어 목록(.text)의 함수 단위 호출 그래프
+// 간선은 직접 call/jmp 와 함수 주소 참조 (lea rdi, [rip + X] / mov edi, 0x...) 로, 함수 포인터로 넘긴 콜백도 도달 가능하게 봄
+type CallGraph struct {
+	starts []uint64                       // 함수 시작 주소 (정렬)
+	edges  map[uint64]map[uint64]struct{} // 함수 시작 주소 -> 호출/참조하는 함수 시작 주소
+}
+
+// BuildCallGraph는 funcStarts(심볼에서 얻은 함수 시작 주소)와 직접 call 대상을 함수 경계로 삼아 호출 그래프를 만듭니다.
+// instructions 는 주소 순이어야 하며 여러 실행 섹션(.init, .text, .fini 등)을 이어 붙인 목록이어도 됩니다.
+// 역어셈된 명령어가 덮는 주소 범위 밖(섹션 사이 틈, PLT 등)을 가리키는 참조는 간선으로 보지 않습니다.
+// 심볼이 없는 코드는 앞쪽의 가장 가까운 함수에 속한 것으로 봅니다. (도달 가능 범위를 넓게 잡는 쪽)
+// ctx가 취소되면 ctx.Err()를 반환합니다.
+func BuildCallGraph(ctx context.Context, instructions []gapstone.Instruction, funcStarts []uint64) (*CallGraph, error) {
+	g := &CallGraph{edges: make(map[uint64]map[uint64]struct{})}
+	if len(instructions) == 0 {
+		return g, nil
+	}
+	inText := codeRanges(instructions).contains
+
+	startSet := make(map[uint64]struct{})
+	for _, addr := range funcStarts {
+		if inText(addr) {
+			startSet[addr] = struct{}{}
+		}
+	}
+	for _, insn := range instructions {
+		if target, ok := branchTarget(insn); ok && inText(target) && strings.HasPrefix(baseMnemonic(insn), "call") {
+			startSet[target] = struct{}{}
+		}
+	}
+	for addr := range startSet {
+		g.starts = append(g.starts, addr)
+	}
+	sort.Slice(g.starts, func(i, j int) bool { return g.starts[i] < g.starts[j] })
+
+	for i, insn := range instructions {
+		if i%ctxCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		from, ok := g.FunctionOf(uint64(insn.Address))
+		if !ok {
+			continue
+		}
+		for _, ref := range codeRefs(insn) {
+			if !inText(ref) {
+				continue
+			}
+			if to, ok := g.FunctionOf(ref); ok && to != from {
+				if g.edges[from] == nil {
+					g.edges[from] = make(map[uint64]struct{})
+				}
+				g.edges[from][to] = struct{}{}
+			}
+		}
+	}
+	return g, nil
+}
+
+// FunctionOf : 주소가 속한 함수의 시작 주소 (첫 함수보다 앞이면 false)
+func (g *CallGraph) FunctionOf(addr uint64) (uint64, bool) {
+	i := sort.Search(len(g.starts), func(i int) bool { return g.starts[i] > addr }) - 1
+	if i < 0 {
+		return 0, false
+	}
+	return g.starts[i], true
+}
+
+// Functions : 그래프의 함수 수
+func (g *CallGraph) Functions() int {
+	return len(g.starts)
+}
+
+// Reachable : roots(진입점 주소)가 속한 함수에서 간선을 따라 도달 가능한 함수 시작 주소 집합
+func (g *CallGraph) Reachable(roots []uint64) map[uint64]struct{} {
+	seen := make(map[uint64]struct{})
+	var queue []uint64
+	for _, root := range roots {
+		if fn, ok := g.FunctionOf(root); ok {
+			if _, dup := seen[fn]; !dup {
+				seen[fn] = struct{}{}
+				queue = append(queue, fn)
+			}
+		}
+	}
+	for len(queue) > 0 {
+		fn := queue[0]
+		queue = queue[1:]
+		for to := range g.edges[fn] {
+			if _, ok := seen[to]; !ok {
+				seen[to] = struct{}{}
+				queue = append(queue, to)
+			}
+		}
+	}
+	return seen
+}
+
+// addrRange는 연속된 명령어가 덮는 주소 범위 [lo, hi)
+type addrRange struct{ lo, hi uint64 }
+
+// addrRanges는 주소 순으로 정렬된 겹치지 않는 범위 목록
+type addrRanges []addrRange
+
+// codeRanges : 주소 순 명령어 목록이 덮는 범위 (앞 명령어 끝과 다음 명령어 시작이 이어지지 않으면 새 범위)
+func codeRanges(instructions []gapstone.Instruction) addrRanges {
+	var ranges addrRanges
+	for _, insn := range instructions {
+		start, end := uint64(insn.Address), uint64(insn.Address)+uint64(insn.Size)
+		if n := len(ranges); n > 0 && ranges[n-1].hi == start {
+			ranges[n-1].hi = end
+			continue
+		}
+		ranges = append(ranges, addrRange{start, end})
+	}
+	return ranges
+}
+
+// contains : 주소가 어느 범위 안에 있는지 확인
+func (r addrRanges) contains(addr uint64) bool {
+	i := sort.Search(len(r), func(i int) bool { return r[i].hi > addr })
+	return i < len(r) && addr >= r[i].lo
+}
+
+// codeRefs : 명령어가 가리키는 주소 (분기 대상, RIP 기준 메모리 피연산자, 즉시값)
+func codeRefs(insn gapstone.Instruction) []uint64 {
+	if insn.X86 == nil {
+		return nil
+	}
+	if target, ok := branchTarget(insn); ok {
+		return []uint64{target}
+	}
+	var refs []uint64
+	for _, op := range insn.X86.Operands {
+		if op.Type == gapstone.X86_OP_IMM {
+			refs = append(refs, uint64(op.Imm))
+		} else if addr, ok := ripAddress(insn, op); ok {
+			refs = append(refs, addr)
+		}
+	}
+	return refs
+}
+
+// EntryPoints는 호출 그래프 도달 가능성 분석의 시작점 (종류별 주소)
+type EntryPoints struct {
+	Entry   uint64   // ELF 헤더의 e_entry (보통 _start, 스트립된 바이너리도 항상 있음)
+	Init    []uint64 // 생성자: DT_INIT(_init), .preinit_array, .init_array
+	Fini    []uint64 // 소멸자: DT_FINI(_fini), .fini_array
+	Exports []uint64 // export 된 함수 (다른 모듈이나 dlsym 으로 호출될 수 있음)
+	Data    []uint64 // 데이터에 저장된 코드 주소 (vtable, 콜백 구조체, 시그널 핸들러 표 등 간접 호출 대상)
+	Symbols []uint64 // 이름으로 찾은 시작점 (main 등, .symtab 이 있을 때)
+}
+
+// All : 모든 시작점 주소 (0 제외)
+func (e EntryPoints) All() []uint64 {
+	var roots []uint64
+	if e.Entry != 0 {
+		roots = append(roots, e.Entry)
+	}
+	for _, group := range [][]uint64{e.Init, e.Fini, e.Exports, e.Data, e.Symbols} {
+		for _, addr := range group {
+			if addr != 0 {
+				roots = append(roots, addr)
+			}
+		}
+	}
+	return roots
+}
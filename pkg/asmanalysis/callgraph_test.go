@@ -0,0 +1,157 @@
+package asmanalysis
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/knightsc/gapstone"
+)
+
+// insn : 테스트용 명령어 (주소, 크기, 니모닉, 피연산자)
+func insn(addr, size uint64, mnemonic string, ops ...gapstone.X86Operand) gapstone.Instruction {
+	return gapstone.Instruction{
+		InstructionHeader: gapstone.InstructionHeader{Address: uint(addr), Size: uint(size), Mnemonic: mnemonic},
+		X86:               &gapstone.X86Instruction{Operands: ops},
+	}
+}
+
+func imm(v uint64) gapstone.X86Operand {
+	return gapstone.X86Operand{Type: gapstone.X86_OP_IMM, Imm: int64(v)}
+}
+
+func reg(r uint) gapstone.X86Operand {
+	return gapstone.X86Operand{Type: gapstone.X86_OP_REG, Reg: r}
+}
+
+// rip : [rip + disp]
+func rip(disp int64) gapstone.X86Operand {
+	return gapstone.X86Operand{Type: gapstone.X86_OP_MEM, Mem: gapstone.X86MemoryOperand{
+		Base: gapstone.X86_REG_RIP, Index: gapstone.X86_REG_INVALID, Disp: disp,
+	}}
+}
+
+// testProgram : 두 실행 섹션 (.text 0x1000-0x1040, .fini 0x2000-0x2011)
+//
+//	A 0x1000 : call B
+//	B 0x1010 : lea rdi, [rip + D] (콜백 주소), call PLT(0x500, 범위 밖)
+//	C 0x1020 : call 0x1800 (섹션 사이 틈), jmp B  -- 아무도 부르지 않음
+//	D 0x1030 : ret                               -- B 가 주소만 넘김
+//	E 0x2000 : call F                            -- 소멸자 (.fini)
+//	F 0x2010 : ret
+func testProgram() (insns []gapstone.Instruction, funcStarts []uint64) {
+	insns = []gapstone.Instruction{
+		insn(0x1000, 5, "call", imm(0x1010)),
+		insn(0x1005, 1, "ret"),
+		insn(0x1006, 10, "nop"),
+		insn(0x1010, 7, "lea", reg(gapstone.X86_REG_RDI), rip(0x1030-0x1017)),
+		insn(0x1017, 5, "call", imm(0x500)),
+		insn(0x101c, 1, "ret"),
+		insn(0x101d, 3, "nop"),
+		insn(0x1020, 5, "call", imm(0x1800)),
+		insn(0x1025, 5, "jmp", imm(0x1010)),
+		insn(0x102a, 6, "nop"),
+		insn(0x1030, 1, "ret"),
+		insn(0x1031, 15, "nop"),
+		insn(0x2000, 5, "call", imm(0x2010)),
+		insn(0x2005, 11, "nop"),
+		insn(0x2010, 1, "ret"),
+	}
+	return insns, []uint64{0x1000, 0x1020, 0x1030, 0x2000}
+}
+
+func TestBuildCallGraphFunctions(t *testing.T) {
+	insns, starts := testProgram()
+	g, err := BuildCallGraph(context.Background(), insns, starts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 심볼 4개 + call 대상 B, F (범위 밖 0x500, 0x1800 은 함수가 아님)
+	if got := g.Functions(); got != 6 {
+		t.Errorf("Functions() = %d, want 6 (%v)", got, g.starts)
+	}
+
+	tests := []struct {
+		addr   uint64
+		want   uint64
+		wantOK bool
+	}{
+		{0x0fff, 0, false},
+		{0x1000, 0x1000, true},
+		{0x100f, 0x1000, true},
+		{0x1017, 0x1010, true},
+		{0x1025, 0x1020, true},
+		{0x2005, 0x2000, true},
+		{0x2010, 0x2010, true},
+	}
+	for _, tt := range tests {
+		got, ok := g.FunctionOf(tt.addr)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("FunctionOf(%#x) = %#x, %t, want %#x, %t", tt.addr, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestCallGraphReachable(t *testing.T) {
+	insns, starts := testProgram()
+	g, err := BuildCallGraph(context.Background(), insns, starts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		roots []uint64
+		want  []uint64
+	}{
+		{"entry only", []uint64{0x1000}, []uint64{0x1000, 0x1010, 0x1030}},
+		{"entry and fini", []uint64{0x1000, 0x2000}, []uint64{0x1000, 0x1010, 0x1030, 0x2000, 0x2010}},
+		{"root inside a function", []uint64{0x1005}, []uint64{0x1000, 0x1010, 0x1030}},
+		{"unreferenced function as root", []uint64{0x1020}, []uint64{0x1010, 0x1020, 0x1030}},
+		{"callback only", []uint64{0x1030}, []uint64{0x1030}},
+		{"root before first function", []uint64{0x10}, nil},
+		{"no roots", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reachable := g.Reachable(tt.roots)
+			var got []uint64
+			for fn := range reachable {
+				got = append(got, fn)
+			}
+			sort.Slice(got, func(i, j int) bool { return got[i] < got[j] })
+			if len(got) != len(tt.want) {
+				t.Fatalf("Reachable(%#x) = %#x, want %#x", tt.roots, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("Reachable(%#x) = %#x, want %#x", tt.roots, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestBuildCallGraphCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	insns, starts := testProgram()
+	if _, err := BuildCallGraph(ctx, insns, starts); err != context.Canceled {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+}
+
+func TestCodeRanges(t *testing.T) {
+	insns, _ := testProgram()
+	ranges := codeRanges(insns)
+	if len(ranges) != 2 || ranges[0] != (addrRange{0x1000, 0x1040}) || ranges[1] != (addrRange{0x2000, 0x2011}) {
+		t.Fatalf("codeRanges = %#x", ranges)
+	}
+	for addr, want := range map[uint64]bool{
+		0x0fff: false, 0x1000: true, 0x103f: true, 0x1040: false, 0x1800: false, 0x2000: true, 0x2010: true, 0x2011: false,
+	} {
+		if got := ranges.contains(addr); got != want {
+			t.Errorf("contains(%#x) = %t, want %t", addr, got, want)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package asmanalysis
+
+import (
+	"context"
+	"strings"
+
+	"github.com/knightsc/gapstone"
+)
+
+// NumArgRegs : 호출 지점에서 복원하는 정수 인자 레지스터 수
+const NumArgRegs = 4
+
+// ArgRegNames : System V AMD64 호출 규약의 1~4번째 정수 인자 레지스터
+// (래퍼 호출 기준이며, 커널 시스템 콜 규약에서는 4번째 인자가 rcx 대신 r10)
+var ArgRegNames = [NumArgRegs]string{"rdi", "rsi", "rdx", "rcx"}
+
+// argReg : 인자 레지스터 이름(64/32/16/8비트)별 인자 번호와 크기(바이트)
+type argReg struct {
+	index int
+	size  int
+}
+
+var argRegs = map[uint]argReg{
+	gapstone.X86_REG_RDI: {0, 8}, gapstone.X86_REG_EDI: {0, 4}, gapstone.X86_REG_DI: {0, 2}, gapstone.X86_REG_DIL: {0, 1},
+	gapstone.X86_REG_RSI: {1, 8}, gapstone.X86_REG_ESI: {1, 4}, gapstone.X86_REG_SI: {1, 2}, gapstone.X86_REG_SIL: {1, 1},
+	gapstone.X86_REG_RDX: {2, 8}, gapstone.X86_REG_EDX: {2, 4}, gapstone.X86_REG_DX: {2, 2}, gapstone.X86_REG_DL: {2, 1}, gapstone.X86_REG_DH: {2, 1},
+	gapstone.X86_REG_RCX: {3, 8}, gapstone.X86_REG_ECX: {3, 4}, gapstone.X86_REG_CX: {3, 2}, gapstone.X86_REG_CL: {3, 1}, gapstone.X86_REG_CH: {3, 1},
+}
+
+// readOnlyMnemonics : 첫 번째 피연산자를 읽기만 하는 명령어 (인자 레지스터 값을 바꾸지 않음)
+var readOnlyMnemonics = map[string]struct{}{
+	"cmp": {}, "test": {}, "push": {}, "bt": {},
+}
+
+// clobberMnemonics : 피연산자에 드러나지 않게 인자 레지스터(rdi, rsi, rdx, rcx)를 바꾸는 명령어
+var clobberMnemonics = map[string]struct{}{
+	"syscall": {}, "cpuid": {}, "rdtsc": {}, "rdtscp": {}, "cqo": {}, "cdq": {}, "cwd": {},
+	"mul": {}, "div": {}, "idiv": {}, "movsb": {}, "movsq": {}, "stosb": {}, "stosq": {}, "stosd": {},
+	"scasb": {}, "cmpsb": {}, "xchg": {},
+}
+
+// ArgValue는 호출 시점의 인자 레지스터 값
+type ArgValue struct {
+	Value int64 // Known일 때의 값 (32비트 mov는 0으로 확장한 값)
+	Known bool  // 같은 기본 블록 안의 mov/xor 로 상수임을 확인함
+}
+
+// CallSite는 추적 대상 주소로 가는 call/jmp 또는 주소 참조 하나
+type CallSite struct {
+	Address      uint64               // call/jmp 명령어 주소
+	Target       uint64               // 호출 대상 주소 (PLT 항목, 간접 호출이면 GOT 엔트리)
+	Tail         bool                 // jmp/jcc 로 호출 (꼬리 호출)
+	Indirect     bool                 // "call [rip + X]" 처럼 GOT 엔트리를 거친 호출 (-fno-plt)
+	AddressTaken bool                 // 호출이 아니라 주소만 읽음 (lea/mov 로 함수 포인터를 만듦, Args 는 의미 없음)
+	Args         [NumArgRegs]ArgValue // 호출 시점의 rdi, rsi, rdx, rcx
+}
+
+// FindCallSites는 명령어 목록을 순방향으로 스캔하여 targets 로 가는 call/jmp 와
+// 그 시점에 상수로 확인되는 인자 레지스터 값을 찾습니다.
+// 직접 호출(call 0x...)은 대상 주소, 간접 호출(call [rip + X])은 읽는 메모리 주소를 targets 와 비교합니다.
+// 분기가 아닌 명령어가 targets 주소를 읽으면(lea/mov) AddressTaken 으로 기록합니다.
+// 레지스터 값은 기본 블록 안에서만 추적합니다. (분기 대상, call 이후, jmp/ret 이후에는 모두 모르는 값)
+// ctx가 취소되면 그때까지 찾은 결과와 ctx.Err()를 반환합니다.
+func FindCallSites(ctx context.Context, instructions []gapstone.Instruction, targets map[uint64]struct{}) ([]CallSite, error) {
+	var results []CallSite
+	var regs [NumArgRegs]ArgValue
+
+	jumpTargets := make(map[uint64]struct{})
+	for _, target := range DirectBranchTargets(instructions) {
+		jumpTargets[target] = struct{}{}
+	}
+
+	for i, insn := range instructions {
+		if i%ctxCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return results, err
+			}
+		}
+
+		if _, ok := jumpTargets[uint64(insn.Address)]; ok {
+			regs = [NumArgRegs]ArgValue{} // 다른 경로에서 들어올 수 있는 지점
+		}
+		if insn.X86 == nil {
+			continue
+		}
+
+		if isBranch(insn) {
+			target, ok := branchTarget(insn)
+			indirect := false
+			if !ok {
+				target, ok = RIPTarget(insn)
+				indirect = true
+			}
+			if _, wanted := targets[target]; ok && wanted {
+				results = append(results, CallSite{
+					Address:  uint64(insn.Address),
+					Target:   target,
+					Tail:     strings.HasPrefix(baseMnemonic(insn), "j"),
+					Indirect: indirect,
+					Args:     regs,
+				})
+			}
+			// call 은 인자 레지스터를 보존하지 않고, jmp/ret 다음 명령어는 새 기본 블록 (조건 분기의 다음 명령어는 값 유지)
+			if !isConditionalJump(insn) {
+				regs = [NumArgRegs]ArgValue{}
+			}
+			continue
+		}
+
+		for _, ref := range codeRefs(insn) {
+			if _, wanted := targets[ref]; wanted {
+				results = append(results, CallSite{Address: uint64(insn.Address), Target: ref, AddressTaken: true})
+				break
+			}
+		}
+		trackArgWrite(insn, &regs)
+	}
+	return results, nil
+}
+
+// trackArgWrite : 명령어 하나가 인자 레지스터에 쓰는 값을 반영
+func trackArgWrite(insn gapstone.Instruction, regs *[NumArgRegs]ArgValue) {
+	if _, ok := clobberMnemonics[insn.Mnemonic]; ok || strings.HasPrefix(insn.Mnemonic, "rep") {
+		*regs = [NumArgRegs]ArgValue{}
+		return
+	}
+	ops := insn.X86.Operands
+	if len(ops) == 0 || ops[0].Type != gapstone.X86_OP_REG {
+		return
+	}
+	dst, ok := argRegs[ops[0].Reg]
+	if !ok {
+		return
+	}
+	if _, ok := readOnlyMnemonics[insn.Mnemonic]; ok {
+		return
+	}
+
+	switch {
+	// 'mov edi, 0xN' / 'mov rdi, 0xN' (32비트 쓰기는 상위 32비트를 0으로 채움)
+	case (insn.Mnemonic == "mov" || insn.Mnemonic == "movabs") && len(ops) == 2 && ops[1].Type == gapstone.X86_OP_IMM && dst.size >= 4:
+		v := ops[1].Imm
+		if dst.size == 4 {
+			v = int64(uint32(v))
+		}
+		regs[dst.index] = ArgValue{Value: v, Known: true}
+	// 'xor edi, edi'
+	case insn.Mnemonic == "xor" && len(ops) == 2 && ops[1].Type == gapstone.X86_OP_REG && ops[1].Reg == ops[0].Reg && dst.size >= 4:
+		regs[dst.index] = ArgValue{Value: 0, Known: true}
+	default:
+		regs[dst.index] = ArgValue{}
+	}
+}
+
+func isConditionalJump(insn gapstone.Instruction) bool {
+	m := baseMnemonic(insn)
+	return strings.HasPrefix(m, "j") && m != "jmp"
+}
@@ -0,0 +1,46 @@
+package asmanalysis
+
+import "fmt"
+
+// Confidence는 시스템 콜 번호를 얼마나 직접적으로 확인했는지 나타내는 등급 (값이 클수록 확실)
+// 0은 등급을 기록하지 않은 이전 결과
+type Confidence int
+
+const (
+	ConfidenceTransitive     Confidence = iota + 1 // 래퍼가 직접 jmp/call 하는 다른 함수에서 찾음 (한 단계)
+	ConfidenceHeuristicAlias                       // 이름으로 추정한 다른 심볼("64" 접미사)에서 찾음
+	ConfidencePropagated                           // rax 설정과 syscall 사이에 분기, 분기 대상 또는 다른 syscall 이 있음 (다른 경로의 값이거나 반환값일 수 있음)
+	ConfidenceExact                                // syscall과 같은 기본 블록의 mov/xor 로 설정된 rax
+)
+
+var confidenceNames = map[Confidence]string{
+	ConfidenceTransitive:     "transitive",
+	ConfidenceHeuristicAlias: "heuristic-alias",
+	ConfidencePropagated:     "propagated",
+	ConfidenceExact:          "exact",
+}
+
+func (c Confidence) String() string {
+	if name, ok := confidenceNames[c]; ok {
+		return name
+	}
+	return ""
+}
+
+// Min : 두 등급 중 낮은 쪽 (여러 단계를 거친 결과는 가장 약한 단계의 등급을 따름)
+func (c Confidence) Min(other Confidence) Confidence {
+	if other < c {
+		return other
+	}
+	return c
+}
+
+// ParseConfidence : 등급 이름("exact", "propagated", "heuristic-alias", "transitive")을 Confidence로 변환
+func ParseConfidence(name string) (Confidence, error) {
+	for c, n := range confidenceNames {
+		if n == name {
+			return c, nil
+		}
+	}
+	return 0, fmt.Errorf("알 수 없는 신뢰도: %q (exact, propagated, heuristic-alias, transitive)", name)
+}
@@ -4,27 +4,115 @@ package asmanalysis
 //https://github.com/knightsc/gapstone/blob/master/x86_decomposer.go
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/knightsc/gapstone"
 )
 
+// ctxCheckInterval : 몇 개의 명령어마다 취소 여부를 확인할지
+const ctxCheckInterval = 4096
+
 // SyscallInfo는 발견된 시스템 콜의 정보를 담는 구조체입니다.
 type SyscallInfo struct {
-	Address uint64 // syscall 명령어의 주소
-	Number  int64  // 호출 시점의 rax 값 (시스템 콜 번호)
+	Address    uint64     // syscall 명령어의 주소
+	Number     int64      // 호출 시점의 rax 값 (시스템 콜 번호)
+	Confidence Confidence // Number를 얼마나 직접적으로 확인했는지 (Number가 -1이면 0)
+}
+
+// baseMnemonic : "bnd jmp", "notrack call" 처럼 접두사가 붙은 니모닉에서 접두사를 뗀 이름
+func baseMnemonic(insn gapstone.Instruction) string {
+	m := insn.Mnemonic
+	for _, prefix := range []string{"bnd ", "notrack "} {
+		m = strings.TrimPrefix(m, prefix)
+	}
+	return m
+}
+
+// isBranch : 분기 명령어 (jmp, jcc, call, ret 등) 인지 확인
+func isBranch(insn gapstone.Instruction) bool {
+	m := baseMnemonic(insn)
+	return strings.HasPrefix(m, "j") || strings.HasPrefix(m, "call") || strings.HasPrefix(m, "ret") ||
+		strings.HasPrefix(m, "loop")
+}
+
+// branchTarget : 즉시값 피연산자를 가진 직접 분기(jmp/jcc/call)의 대상 주소
+func branchTarget(insn gapstone.Instruction) (uint64, bool) {
+	if insn.X86 == nil || len(insn.X86.Operands) != 1 || !isBranch(insn) {
+		return 0, false
+	}
+	op := insn.X86.Operands[0]
+	if op.Type != gapstone.X86_OP_IMM {
+		return 0, false
+	}
+	return uint64(op.Imm), true
+}
+
+// RIPTarget : "jmp/call qword ptr [rip + X]" 처럼 RIP 기준 메모리 피연산자 하나가 가리키는 주소 (GOT 엔트리 등)
+func RIPTarget(insn gapstone.Instruction) (uint64, bool) {
+	if insn.X86 == nil || len(insn.X86.Operands) != 1 {
+		return 0, false
+	}
+	return ripAddress(insn, insn.X86.Operands[0])
+}
+
+// ripAddress : 피연산자가 [rip + X] 형태이면 그 주소
+func ripAddress(insn gapstone.Instruction, op gapstone.X86Operand) (uint64, bool) {
+	if op.Type != gapstone.X86_OP_MEM || op.Mem.Base != gapstone.X86_REG_RIP || op.Mem.Index != gapstone.X86_REG_INVALID {
+		return 0, false
+	}
+	return uint64(int64(insn.Address) + int64(insn.Size) + op.Mem.Disp), true
+}
+
+// DirectBranchTargets : 명령어 목록에서 직접 jmp/call 대상 주소 (등장 순, 중복 제거)
+func DirectBranchTargets(instructions []gapstone.Instruction) []uint64 {
+	var targets []uint64
+	seen := make(map[uint64]struct{})
+	for _, insn := range instructions {
+		target, ok := branchTarget(insn)
+		if !ok {
+			continue
+		}
+		if _, dup := seen[target]; !dup {
+			seen[target] = struct{}{}
+			targets = append(targets, target)
+		}
+	}
+	return targets
 }
 
 // FindAllSyscalls는 디스셈블된 명령어 목록(함수 코드)을 순방향으로 스캔하여
 // 모든 'syscall' 명령어와 그 시점의 '%rax' 레지스터 값을 찾아 슬라이스로 반환합니다.
-func FindAllSyscalls(instructions []gapstone.Instruction) ([]SyscallInfo, error) {
+// ctx가 취소되면 그때까지 찾은 결과와 ctx.Err()를 반환합니다. (.text 전체처럼 긴 목록을 스캔할 때)
+func FindAllSyscalls(ctx context.Context, instructions []gapstone.Instruction) ([]SyscallInfo, error) {
 	var results []SyscallInfo
 
 	// rax 레지스터의 마지막 값을 추적하기 위한 변수.
 	// -1은 아직 rax 값이 설정된 적 없음을 의미하는 초기값.
 	lastRaxValue := int64(-1)
+	// raxCarried : rax를 설정한 뒤 분기를 지났거나 분기 대상(다른 경로로 들어올 수 있는 지점)을 지남
+	// 이 경우 순방향 스캔의 값이 실제 실행 경로의 값과 다를 수 있으므로 ConfidencePropagated
+	raxCarried := false
+	// raxReused : rax를 설정한 뒤 이미 syscall 을 지남 (커널이 rax 에 반환값을 쓰므로 같은 번호라는 보장이 없음)
+	raxReused := false
+	jumpTargets := make(map[uint64]struct{})
+	for _, target := range DirectBranchTargets(instructions) {
+		jumpTargets[target] = struct{}{}
+	}
+
+	for i, insn := range instructions {
+		if i%ctxCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return results, err
+			}
+		}
+
+		if _, ok := jumpTargets[uint64(insn.Address)]; ok {
+			raxCarried = true
+		}
 
-	for _, insn := range instructions {
 		// X86 관련 정보가 없는 명령어 방어코드
 		if insn.X86 == nil {
 			continue
@@ -42,6 +130,7 @@ func FindAllSyscalls(instructions []gapstone.Instruction) ([]SyscallInfo, error)
 
 			if isRaxEax && isImm {
 				lastRaxValue = op1.Imm
+				raxCarried, raxReused = false, false
 			}
 		}
 
@@ -55,6 +144,7 @@ func FindAllSyscalls(instructions []gapstone.Instruction) ([]SyscallInfo, error)
 
 			if isEax && isSame {
 				lastRaxValue = 0 // 'xor eax, eax'는 0을 의미
+				raxCarried, raxReused = false, false
 			}
 		}
 
@@ -64,12 +154,19 @@ func FindAllSyscalls(instructions []gapstone.Instruction) ([]SyscallInfo, error)
 			// syscall을 찾았을 때, 이전에 rax 값이 설정된 적이 있다면
 			if lastRaxValue != -1 {
 				// 결과 목록에 추가
+				confidence := ConfidenceExact
+				if raxCarried || raxReused {
+					confidence = ConfidencePropagated
+				}
 				results = append(results, SyscallInfo{
-					Address: uint64(insn.Address),
-					Number:  lastRaxValue,
+					Address:    uint64(insn.Address),
+					Number:     lastRaxValue,
+					Confidence: confidence,
 				})
 				// lastRaxValue를 초기화하지 않습니다.
 				// (동일한 rax 값으로 여러 syscall을 호출하는 패턴이 있을 수 있으므로)
+				// 다만 syscall 뒤의 rax 는 반환값이므로, 다시 설정하기 전의 syscall 은 ConfidencePropagated
+				raxReused = true
 			} else {
 				// rax 값이 설정되지 않았는데 syscall이 호출된 경우
 				// (예: 함수 초입에서 rax가 설정되고 분기 없이 바로 syscall)
@@ -78,9 +175,13 @@ func FindAllSyscalls(instructions []gapstone.Instruction) ([]SyscallInfo, error)
 					Address: uint64(insn.Address),
 					Number:  -1, // -1은 추적 실패를 의미
 				})
-				fmt.Printf("경고: 0x%x에서 rax 값이 설정되지 않은 syscall 호출 발견\n", insn.Address)
+				fmt.Fprintf(os.Stderr, "경고: 0x%x에서 rax 값이 설정되지 않은 syscall 호출 발견\n", insn.Address)
 			}
 		}
+
+		if isBranch(insn) {
+			raxCarried = true
+		}
 	}
 	return results, nil // result에는 시스콜 호출 주소하고 호출시 rax 인자값들어있음
 }
@@ -0,0 +1,101 @@
+// pkg/bpfgen/generator.go
+package bpfgen
+
+import (
+	"bytes"
+	"fmt"
+	"ips_bpf/static-analyzer/pkg/syscalls"
+	"sort"
+	"text/template"
+)
+
+// Handler는 하나의 sys_enter Tracepoint 핸들러 정보
+type Handler struct {
+	Syscall  string   // 커널 시스템 콜 이름 (예: "openat")
+	Number   int64    // x86_64 시스템 콜 번호
+	Wrappers []string // 이 시스템 콜로 매핑된 libc 래퍼들 (예: ["open", "openat"])
+}
+
+// Tracepoint : "syscalls/sys_enter_<name>" 형태의 Tracepoint 경로
+func (h Handler) Tracepoint() string {
+	return "syscalls/sys_enter_" + h.Syscall
+}
+
+// Program : 생성될 BPF 프로그램(함수) 이름
+func (h Handler) Program() string {
+	return "handle_sys_enter_" + h.Syscall
+}
+
+// Options는 생성 시 선택 가능한 값들
+type Options struct {
+	Target      string // 분석 대상 경로 (생성 코드 주석용)
+	GoPackage   string // Go 로더 스텁의 패키지 이름 (기본: "ipsbpf")
+	ObjectName  string // clang으로 빌드될 오브젝트 파일 이름 (기본: "ips_syscalls.bpf.o")
+	RingBufSize int    // 링 버퍼 크기 (바이트, 기본: 256KiB)
+}
+
+// Output은 생성 결과
+type Output struct {
+	Handlers  []Handler
+	Skipped   []string // Tracepoint가 없어 핸들러를 만들지 않은 시스템 콜
+	BPFSource []byte   // libbpf 스타일 C 소스
+	GoLoader  []byte   // cilium/ebpf 기반 Go 로더 스텁
+}
+
+// Generate : 최종 {wrapper: kernelSyscall} 맵으로부터 Tracepoint 핸들러 C 소스와 Go 로더 스텁 생성
+func Generate(syscallMap map[string]string, opts Options) (*Output, error) {
+	if opts.GoPackage == "" {
+		opts.GoPackage = "ipsbpf"
+	}
+	if opts.ObjectName == "" {
+		opts.ObjectName = "ips_syscalls.bpf.o"
+	}
+	if opts.RingBufSize == 0 {
+		opts.RingBufSize = 256 * 1024
+	}
+
+	// 커널 시스템 콜 이름 기준으로 래퍼 묶기
+	wrappersBySyscall := make(map[string][]string)
+	for wrapperName, kernelName := range syscallMap {
+		if kernelName == "" {
+			continue
+		}
+		wrappersBySyscall[kernelName] = append(wrappersBySyscall[kernelName], wrapperName)
+	}
+
+	out := &Output{}
+	for kernelName, wrappers := range wrappersBySyscall {
+		num, ok := syscalls.GetKernelSyscallNumber(kernelName)
+		if !ok || !syscalls.IsTracepointAvailable(kernelName) {
+			out.Skipped = append(out.Skipped, kernelName)
+			continue
+		}
+		sort.Strings(wrappers)
+		out.Handlers = append(out.Handlers, Handler{Syscall: kernelName, Number: num, Wrappers: wrappers})
+	}
+	// 동일 입력에 대해 항상 같은 소스가 생성되도록 번호순 정렬
+	sort.Slice(out.Handlers, func(i, j int) bool { return out.Handlers[i].Number < out.Handlers[j].Number })
+	sort.Strings(out.Skipped)
+
+	data := struct {
+		Options
+		Handlers []Handler
+	}{opts, out.Handlers}
+
+	var err error
+	if out.BPFSource, err = execute(bpfSourceTemplate, data); err != nil {
+		return nil, fmt.Errorf("BPF C 소스 생성 실패: %w", err)
+	}
+	if out.GoLoader, err = execute(goLoaderTemplate, data); err != nil {
+		return nil, fmt.Errorf("Go 로더 스텁 생성 실패: %w", err)
+	}
+	return out, nil
+}
+
+func execute(tmpl *template.Template, data interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
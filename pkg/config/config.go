@@ -23,3 +23,23 @@
 		// Job 매니페스트에서 Secret을 통해 주입될 것입니다.
 		return os.Getenv("CCSL_REDIS_PASSWORD")
 	}
+
+	// [신규] LoadStoreKind는 환경 변수에서 결과 저장소 종류(redis, file)를 로드합니다.
+	func LoadStoreKind() string {
+		if kind := os.Getenv("CCSL_STORE"); kind != "" {
+			return kind
+		}
+		return "redis" // 기존 Job 동작 유지
+	}
+
+	// [신규] LoadStoreDir는 file 저장소에서 사용할 디렉터리를 로드합니다.
+	func LoadStoreDir() string {
+		if dir := os.Getenv("CCSL_STORE_DIR"); dir != "" {
+			return dir
+		}
+		return "./results"
+	}
+
+	// [신규] AnalyzerVersion은 결과 메타데이터에 기록되는 분석기 버전입니다.
+	// 빌드 시 -ldflags "-X ips_bpf/static-analyzer/pkg/config.AnalyzerVersion=<태그>" 로 덮어쓸 수 있습니다.
+	var AnalyzerVersion = "0.7.1"
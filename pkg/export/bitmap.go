@@ -0,0 +1,101 @@
+// pkg/export/bitmap.go
+package export
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"ips_bpf/static-analyzer/pkg/syscalls"
+	"sort"
+)
+
+// BitmapArch는 비트맵/Tetragon 번호 변환이 지원하는 유일한 아키텍처
+// 시스템 콜 번호 표(syscalls.GetKernelSyscallNumber)가 x86_64 번호만 가지고 있으므로
+// 다른 아키텍처(예: aarch64)의 대상은 비트맵을 만들지 않고 오류를 반환
+const BitmapArch = "x86_64"
+
+// CheckArch : 대상 ELF 아키텍처(analyzer.ELFAnalyzer.Arch)가 비트맵 번호 표와 맞는지 확인
+func CheckArch(arch string) error {
+	if arch != BitmapArch {
+		return fmt.Errorf("지원하지 않는 아키텍처 %q: 시스템 콜 번호 표는 %s 만 지원", arch, BitmapArch)
+	}
+	return nil
+}
+
+// SyscallBitmapBits는 허용 비트맵이 표현하는 시스템 콜 번호 범위 (0 ~ 511)
+const SyscallBitmapBits = 512
+
+// SyscallBitmap은 BPF 배열 맵 값으로 그대로 쓰는 허용 비트맵 (64바이트)
+// 시스템 콜 n 은 바이트 n/8 의 비트 n%8 에 대응하며,
+// 리틀엔디언 기준 BPF 쪽 `__u64 bits[8]` 의 bits[n/64] & (1ULL << (n%64)) 와 같은 배치
+type SyscallBitmap [SyscallBitmapBits / 8]byte
+
+// Set : 시스템 콜 번호 비트를 켬, 범위를 벗어나면 false
+func (b *SyscallBitmap) Set(nr int64) bool {
+	if nr < 0 || nr >= SyscallBitmapBits {
+		return false
+	}
+	b[nr/8] |= 1 << (uint(nr) % 8)
+	return true
+}
+
+// Has : 시스템 콜 번호 비트가 켜져 있는지 확인
+func (b *SyscallBitmap) Has(nr int64) bool {
+	if nr < 0 || nr >= SyscallBitmapBits {
+		return false
+	}
+	return b[nr/8]&(1<<(uint(nr)%8)) != 0
+}
+
+// Words : 비트맵을 리틀엔디언 uint64 8개로 변환 (BPF 쪽 __u64 bits[8] 와 동일)
+func (b *SyscallBitmap) Words() []uint64 {
+	words := make([]uint64, len(b)/8)
+	for i := range words {
+		words[i] = binary.LittleEndian.Uint64(b[i*8:])
+	}
+	return words
+}
+
+// SyscallEntry는 비트맵에 포함된 시스템 콜 하나
+type SyscallEntry struct {
+	Name   string `json:"name"`
+	Number int64  `json:"nr"`
+}
+
+// ArchBitmap은 아키텍처 하나에 대한 허용 비트맵
+type ArchBitmap struct {
+	Arch     string         `json:"arch"`     // 예: "x86_64"
+	Bits     int            `json:"bits"`     // 항상 SyscallBitmapBits
+	Words    []uint64       `json:"words"`    // bpf_map_update_elem 값으로 쓸 __u64[8]
+	Hex      string         `json:"hex"`      // 64바이트 원본의 hex 인코딩
+	Syscalls []SyscallEntry `json:"syscalls"` // 비트가 켜진 시스템 콜 (번호순)
+	Bitmap   SyscallBitmap  `json:"-"`
+}
+
+// BitmapDocument는 bitmap-json 형식의 최상위 구조체
+type BitmapDocument struct {
+	Target  string       `json:"target"`
+	Bitmaps []ArchBitmap `json:"bitmaps"`
+}
+
+// BuildSyscallBitmap : {wrapper: kernelSyscall} 맵의 커널 시스템 콜들을 arch 번호 비트맵으로 변환
+// arch 가 BitmapArch 가 아니면 오류, 번호를 알 수 없는 이름은 unknown 으로 반환
+func BuildSyscallBitmap(arch string, syscallMap map[string]string) (bm ArchBitmap, unknown []string, err error) {
+	if err := CheckArch(arch); err != nil {
+		return ArchBitmap{}, nil, err
+	}
+	bm = ArchBitmap{Arch: arch, Bits: SyscallBitmapBits}
+	for _, name := range AllowList(syscallMap) {
+		nr, ok := syscalls.GetKernelSyscallNumber(name)
+		if !ok || !bm.Bitmap.Set(nr) {
+			unknown = append(unknown, name)
+			continue
+		}
+		bm.Syscalls = append(bm.Syscalls, SyscallEntry{Name: name, Number: nr})
+	}
+	sort.Slice(bm.Syscalls, func(i, j int) bool { return bm.Syscalls[i].Number < bm.Syscalls[j].Number })
+
+	bm.Words = bm.Bitmap.Words()
+	bm.Hex = hex.EncodeToString(bm.Bitmap[:])
+	return bm, unknown, nil
+}
@@ -0,0 +1,119 @@
+package export
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+// TestSyscallBitmapSet : 시스템 콜 번호 n 이 바이트 n/8 의 비트 n%8, __u64 bits[n/64] 의 비트 n%64 에 놓이는지 확인
+func TestSyscallBitmapSet(t *testing.T) {
+	tests := []struct {
+		nr       int64
+		byteIdx  int
+		byteMask byte
+		wordIdx  int
+		word     uint64
+	}{
+		{nr: 0, byteIdx: 0, byteMask: 0x01, wordIdx: 0, word: 1},
+		{nr: 63, byteIdx: 7, byteMask: 0x80, wordIdx: 0, word: 1 << 63},
+		{nr: 64, byteIdx: 8, byteMask: 0x01, wordIdx: 1, word: 1},
+		{nr: 511, byteIdx: 63, byteMask: 0x80, wordIdx: 7, word: 1 << 63},
+	}
+	for _, tt := range tests {
+		var b SyscallBitmap
+		if !b.Set(tt.nr) {
+			t.Fatalf("Set(%d) = false", tt.nr)
+		}
+		if !b.Has(tt.nr) {
+			t.Errorf("Has(%d) = false", tt.nr)
+		}
+		for i, v := range b {
+			want := byte(0)
+			if i == tt.byteIdx {
+				want = tt.byteMask
+			}
+			if v != want {
+				t.Errorf("nr %d: byte[%d] = %#02x, want %#02x", tt.nr, i, v, want)
+			}
+		}
+		words := b.Words()
+		if len(words) != 8 {
+			t.Fatalf("len(Words()) = %d, want 8", len(words))
+		}
+		for i, w := range words {
+			want := uint64(0)
+			if i == tt.wordIdx {
+				want = tt.word
+			}
+			if w != want {
+				t.Errorf("nr %d: words[%d] = %#x, want %#x", tt.nr, i, w, want)
+			}
+		}
+	}
+}
+
+// TestSyscallBitmapOutOfRange : 범위를 벗어난 번호는 켜지지 않음
+func TestSyscallBitmapOutOfRange(t *testing.T) {
+	for _, nr := range []int64{-1, SyscallBitmapBits, 1 << 40} {
+		var b SyscallBitmap
+		if b.Set(nr) {
+			t.Errorf("Set(%d) = true, want false", nr)
+		}
+		if b.Has(nr) {
+			t.Errorf("Has(%d) = true, want false", nr)
+		}
+		if b != (SyscallBitmap{}) {
+			t.Errorf("Set(%d) changed the bitmap", nr)
+		}
+	}
+}
+
+// TestBuildSyscallBitmap : 커널 시스템 콜 이름을 번호 비트로 변환하고, 모르는 이름은 unknown 으로 반환
+func TestBuildSyscallBitmap(t *testing.T) {
+	bm, unknown, err := BuildSyscallBitmap(BitmapArch, map[string]string{
+		"read":      "read",   // 0
+		"__uname":   "uname",  // 63
+		"semget":    "semget", // 64
+		"pread":     "read",   // 같은 커널 시스템 콜은 한 번만
+		"made_up":   "not_a_syscall",
+		"unresolve": "",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if bm.Arch != "x86_64" {
+		t.Errorf("Arch = %q, want x86_64", bm.Arch)
+	}
+	if len(unknown) != 1 || unknown[0] != "not_a_syscall" {
+		t.Errorf("unknown = %v, want [not_a_syscall]", unknown)
+	}
+	wantEntries := []SyscallEntry{{"read", 0}, {"uname", 63}, {"semget", 64}}
+	if len(bm.Syscalls) != len(wantEntries) {
+		t.Fatalf("Syscalls = %v, want %v", bm.Syscalls, wantEntries)
+	}
+	for i, e := range wantEntries {
+		if bm.Syscalls[i] != e {
+			t.Errorf("Syscalls[%d] = %v, want %v", i, bm.Syscalls[i], e)
+		}
+	}
+	if bm.Words[0] != 1|1<<63 || bm.Words[1] != 1 {
+		t.Errorf("Words = %#x", bm.Words)
+	}
+	if bm.Hex != hex.EncodeToString(bm.Bitmap[:]) || len(bm.Hex) != SyscallBitmapBits/4 {
+		t.Errorf("Hex = %q", bm.Hex)
+	}
+}
+
+// TestBuildSyscallBitmapUnsupportedArch : x86_64 가 아닌 아키텍처는 x86_64 번호 비트맵 대신 오류
+func TestBuildSyscallBitmapUnsupportedArch(t *testing.T) {
+	for _, arch := range []string{"EM_AARCH64", "EM_386", ""} {
+		bm, unknown, err := BuildSyscallBitmap(arch, map[string]string{"read": "read"})
+		if err == nil {
+			t.Errorf("BuildSyscallBitmap(%q) = nil error, want unsupported arch", arch)
+		}
+		if len(bm.Syscalls) != 0 || len(unknown) != 0 || bm.Bitmap != (SyscallBitmap{}) {
+			t.Errorf("BuildSyscallBitmap(%q) = %+v, %v, want empty", arch, bm, unknown)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+// pkg/export/capabilities.go
+package export
+
+import (
+	"ips_bpf/static-analyzer/pkg/syscalls"
+	"strings"
+)
+
+// SecurityContext는 Pod/컨테이너 securityContext 중 capability 관련 부분
+type SecurityContext struct {
+	Capabilities Capabilities `json:"capabilities"`
+}
+
+// Capabilities는 securityContext.capabilities (Kubernetes는 CAP_ 접두사 없는 이름을 사용)
+type Capabilities struct {
+	Add  []string `json:"add,omitempty"`
+	Drop []string `json:"drop"`
+}
+
+// CapabilityDocument는 capabilities 형식의 최상위 구조체
+type CapabilityDocument struct {
+	Target          string                    `json:"target"`
+	SecurityContext SecurityContext           `json:"securityContext"`
+	Report          syscalls.CapabilityReport `json:"report"`
+}
+
+// BuildCapabilityDocument : {wrapper: kernelSyscall} 맵으로 capability 보고서와 제안 securityContext 생성
+// 기본값은 모두 drop 하고, 발견된 시스템 콜이 항상 요구하는 capability만 add
+// 인자에 따라서만 필요한 capability (report.MayRequire, 예: prlimit64 의 CAP_SYS_RESOURCE)는 보고서에만 남김
+func BuildCapabilityDocument(targetPath string, syscallMap map[string]string) CapabilityDocument {
+	report := syscalls.BuildCapabilityReport(AllowList(syscallMap))
+	return CapabilityDocument{
+		Target:          targetPath,
+		SecurityContext: SuggestSecurityContext(report),
+		Report:          report,
+	}
+}
+
+// SuggestSecurityContext : capability 보고서로 securityContext.capabilities 제안값 생성 (MayRequire 는 add 하지 않음)
+func SuggestSecurityContext(report syscalls.CapabilityReport) SecurityContext {
+	var add []string
+	for _, c := range report.CapabilityNames() {
+		add = append(add, strings.TrimPrefix(c, "CAP_"))
+	}
+	return SecurityContext{Capabilities: Capabilities{Add: add, Drop: []string{"ALL"}}}
+}
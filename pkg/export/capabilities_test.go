@@ -0,0 +1,79 @@
+package export
+
+import (
+	"ips_bpf/static-analyzer/pkg/syscalls"
+	"testing"
+)
+
+// TestSuggestSecurityContext : 항상 capability 가 필요한 시스템 콜만 add 에 들어가고,
+// 인자에 따라 필요한 시스템 콜(chown, setpriority, prlimit64 등)의 capability 는 may_require 에만 남는지 확인
+func TestSuggestSecurityContext(t *testing.T) {
+	tests := []struct {
+		name       string
+		syscalls   []string
+		wantAdd    []string
+		wantMayReq []string
+	}{
+		{
+			name:       "glibc getrlimit/chown only",
+			syscalls:   []string{"read", "prlimit64", "chown", "fchown", "fchownat", "setpriority"},
+			wantMayReq: []string{"CAP_CHOWN", "CAP_SYS_NICE", "CAP_SYS_RESOURCE"},
+		},
+		{
+			name:     "always privileged",
+			syscalls: []string{"mount", "init_module", "ptrace"},
+			wantAdd:  []string{"SYS_ADMIN", "SYS_MODULE", "SYS_PTRACE"},
+		},
+		{
+			name:       "mixed",
+			syscalls:   []string{"mount", "unshare", "prlimit64"},
+			wantAdd:    []string{"SYS_ADMIN"}, // unshare 의 CAP_SYS_ADMIN 은 mount 때문에 이미 필요
+			wantMayReq: []string{"CAP_SYS_RESOURCE"},
+		},
+		{
+			name:     "no capabilities",
+			syscalls: []string{"read", "write", "openat"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			syscallMap := make(map[string]string, len(tt.syscalls))
+			for _, name := range tt.syscalls {
+				syscallMap[name] = name
+			}
+			doc := BuildCapabilityDocument("/bin/app", syscallMap)
+
+			caps := doc.SecurityContext.Capabilities
+			if !sameStrings(caps.Add, tt.wantAdd) {
+				t.Errorf("add = %v, want %v", caps.Add, tt.wantAdd)
+			}
+			if len(caps.Drop) != 1 || caps.Drop[0] != "ALL" {
+				t.Errorf("drop = %v, want [ALL]", caps.Drop)
+			}
+			if got := usageNames(doc.Report.MayRequire); !sameStrings(got, tt.wantMayReq) {
+				t.Errorf("may_require = %v, want %v", got, tt.wantMayReq)
+			}
+		})
+	}
+}
+
+func usageNames(usages []syscalls.CapabilityUsage) []string {
+	var names []string
+	for _, u := range usages {
+		names = append(names, u.Capability)
+	}
+	return names
+}
+
+// sameStrings : nil 과 빈 슬라이스를 같게 보는 비교
+func sameStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,90 @@
+// pkg/export/falco.go
+package export
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// PolicyScope는 생성된 정책이 적용될 대상을 지정
+// Image가 있으면 컨테이너 이미지 기준, 없으면 실행 파일 경로 기준으로 범위를 좁힘
+type PolicyScope struct {
+	Image      string // 컨테이너 이미지 저장소 (예: "shkch.duckdns.org/app/server")
+	BinaryPath string // 컨테이너 안의 실행 파일 경로 (예: "/syscalltest2")
+}
+
+// FalcoOptions는 Falco 규칙 생성 시 선택 가능한 값들
+type FalcoOptions struct {
+	Scope    PolicyScope
+	Name     string // 비어 있으면 분석 대상 파일명으로 생성
+	Priority string // 비어 있으면 WARNING
+}
+
+var falcoRuleTemplate = template.Must(template.New("falco").Funcs(template.FuncMap{
+	"quote": strconv.Quote,
+}).Parse(`# Code generated by elf-static-analyzer. DO NOT EDIT.
+# target: {{.Target}}
+# Falco는 기본적으로 일부 시스템 콜만 수집하므로 전체 목록을 검사하려면 -A 옵션(또는 base_syscalls)이 필요합니다.
+
+- list: {{.ID}}_allowed_syscalls
+  items: [{{range $i, $s := .Syscalls}}{{if $i}}, {{end}}{{$s}}{{end}}]
+
+- macro: {{.ID}}_scope
+  condition: {{.ScopeCondition}}
+
+- rule: Syscall not in allowed list for {{.Name}}
+  desc: >
+    {{.Name}} 에 대해 정적 분석으로 얻은 허용 목록에 없는 시스템 콜이 호출됨
+  condition: >
+    evt.dir = > and {{.ID}}_scope and not evt.type in ({{.ID}}_allowed_syscalls)
+  output: >
+    Syscall not in allowed list (syscall=%evt.type proc=%proc.exepath pid=%proc.pid
+    container=%container.id image=%container.image.repository)
+  priority: {{.Priority}}
+  tags: [container, syscall, ips]
+`))
+
+var falcoIDChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// BuildFalcoRule : {wrapper: kernelSyscall} 맵으로 "허용 목록 밖 시스템 콜" Falco 규칙 YAML 생성
+func BuildFalcoRule(targetPath string, syscallMap map[string]string, opts FalcoOptions) ([]byte, error) {
+	name := opts.Name
+	if name == "" {
+		name = ProfileName(targetPath)
+	}
+	priority := opts.Priority
+	if priority == "" {
+		priority = "WARNING"
+	}
+
+	var scope string
+	switch {
+	case opts.Scope.Image != "":
+		scope = "container.image.repository = " + strconv.Quote(opts.Scope.Image)
+	case opts.Scope.BinaryPath != "":
+		scope = "proc.exepath = " + strconv.Quote(opts.Scope.BinaryPath)
+	default:
+		scope = "proc.exepath = " + strconv.Quote(targetPath)
+	}
+
+	data := struct {
+		Target, Name, ID, ScopeCondition, Priority string
+		Syscalls                                   []string
+	}{
+		Target:         targetPath,
+		Name:           name,
+		ID:             strings.Trim(falcoIDChars.ReplaceAllString(strings.ToLower(name), "_"), "_"),
+		ScopeCondition: scope,
+		Priority:       priority,
+		Syscalls:       AllowList(syscallMap),
+	}
+
+	var buf bytes.Buffer
+	if err := falcoRuleTemplate.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,129 @@
+// pkg/export/seccomp.go
+package export
+
+import (
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// security-profiles-operator SeccompProfile CRD 식별자
+const (
+	SeccompProfileAPIVersion = "security-profiles-operator.x-k8s.io/v1beta1"
+	SeccompProfileKind       = "SeccompProfile"
+
+	// DefaultBaseProfile : 기본 baseProfileName (컨테이너 런타임 기동에 필요한 시스템 콜)
+	// 분석 결과에는 runc 가 exec 전에 호출하는 시스템 콜이 없으므로, 베이스 없이 적용하면 컨테이너가 시작되지 않음
+	DefaultBaseProfile = "runc-v1.1.12"
+)
+
+// SeccompProfile은 security-profiles-operator가 처리하는 SeccompProfile 매니페스트 구조체
+// kubectl은 JSON 매니페스트도 그대로 apply 할 수 있으므로 JSON 태그만 사용
+type SeccompProfile struct {
+	APIVersion string             `json:"apiVersion"`
+	Kind       string             `json:"kind"`
+	Metadata   ObjectMeta         `json:"metadata"`
+	Spec       SeccompProfileSpec `json:"spec"`
+}
+
+// ObjectMeta는 매니페스트에 필요한 최소한의 Kubernetes 메타데이터
+type ObjectMeta struct {
+	Name        string            `json:"name"`
+	Namespace   string            `json:"namespace,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// SeccompProfileSpec은 SeccompProfile의 spec 필드
+type SeccompProfileSpec struct {
+	BaseProfileName string           `json:"baseProfileName,omitempty"` // 예: runc 기동에 필요한 시스템 콜을 담은 베이스 프로파일
+	DefaultAction   string           `json:"defaultAction"`
+	Architectures   []string         `json:"architectures,omitempty"`
+	Syscalls        []SeccompSyscall `json:"syscalls,omitempty"`
+}
+
+// SeccompSyscall은 동일한 action이 적용되는 시스템 콜 묶음
+type SeccompSyscall struct {
+	Action string   `json:"action"`
+	Names  []string `json:"names"`
+}
+
+// SeccompOptions는 프로파일 생성 시 선택 가능한 값들
+type SeccompOptions struct {
+	Name            string // 비어 있으면 분석 대상 파일명으로 생성
+	Namespace       string
+	BaseProfileName string
+	DefaultAction   string // 비어 있으면 SCMP_ACT_ERRNO
+}
+
+// AllowList : {wrapper: kernelSyscall} 맵에서 커널 시스템 콜 이름만 중복 없이 정렬하여 반환
+func AllowList(syscallMap map[string]string) []string {
+	set := make(map[string]struct{})
+	for _, kernelName := range syscallMap {
+		if kernelName != "" {
+			set[kernelName] = struct{}{}
+		}
+	}
+
+	names := make([]string, 0, len(set))
+	for name := range set {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// BuildSeccompProfile : 분석 대상 경로와 {wrapper: kernelSyscall} 맵으로 SeccompProfile 매니페스트 생성
+func BuildSeccompProfile(targetPath string, syscallMap map[string]string, opts SeccompOptions) SeccompProfile {
+	name := opts.Name
+	if name == "" {
+		name = ProfileName(targetPath)
+	}
+	defaultAction := opts.DefaultAction
+	if defaultAction == "" {
+		defaultAction = "SCMP_ACT_ERRNO"
+	}
+
+	profile := SeccompProfile{
+		APIVersion: SeccompProfileAPIVersion,
+		Kind:       SeccompProfileKind,
+		Metadata: ObjectMeta{
+			Name:      name,
+			Namespace: opts.Namespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/managed-by": "elf-static-analyzer",
+			},
+			Annotations: map[string]string{
+				"elf-static-analyzer/target": targetPath,
+			},
+		},
+		Spec: SeccompProfileSpec{
+			BaseProfileName: opts.BaseProfileName,
+			DefaultAction:   defaultAction,
+			Architectures:   []string{"SCMP_ARCH_X86_64"}, // 현재 분석기는 x86_64 libc만 추적
+		},
+	}
+
+	if allowed := AllowList(syscallMap); len(allowed) > 0 {
+		profile.Spec.Syscalls = []SeccompSyscall{{Action: "SCMP_ACT_ALLOW", Names: allowed}}
+	}
+	return profile
+}
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9.-]+`)
+
+// ProfileName : 파일 경로(또는 이미지 이름)를 RFC 1123 규칙에 맞는 리소스 이름으로 변환
+// 예: "/usr/bin/My_Server" -> "my-server"
+func ProfileName(targetPath string) string {
+	name := strings.ToLower(filepath.Base(targetPath))
+	name = invalidNameChars.ReplaceAllString(name, "-")
+	name = strings.Trim(name, "-.")
+	if len(name) > 253 {
+		name = strings.Trim(name[:253], "-.")
+	}
+	if name == "" || name == "/" {
+		return "elf-analyzer-profile"
+	}
+	return name
+}
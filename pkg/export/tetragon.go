@@ -0,0 +1,140 @@
+// pkg/export/tetragon.go
+package export
+
+import "strconv"
+
+// Tetragon TracingPolicy 식별자
+const (
+	TracingPolicyAPIVersion = "cilium.io/v1alpha1"
+	TracingPolicyKind       = "TracingPolicy"
+)
+
+// raw_syscalls:sys_enter Tracepoint에서 시스템 콜 번호(id)가 들어 있는 인자 인덱스 (args 에서만 사용)
+const rawSyscallIDArgIndex = 4
+
+// syscallIDMatchIndex : matchArgs 가 가리키는 정책 args 목록 안의 위치 (args 에는 시스템 콜 id 하나만 선언)
+// matchArgs.index 는 Tracepoint 필드 번호가 아니라 args 목록의 순서이므로 rawSyscallIDArgIndex 와 다름
+const syscallIDMatchIndex = 0
+
+// TracingPolicy는 Tetragon TracingPolicy 매니페스트 구조체 (필요한 필드만 정의)
+type TracingPolicy struct {
+	APIVersion string            `json:"apiVersion"`
+	Kind       string            `json:"kind"`
+	Metadata   ObjectMeta        `json:"metadata"`
+	Spec       TracingPolicySpec `json:"spec"`
+}
+
+// TracingPolicySpec은 TracingPolicy의 spec 필드
+type TracingPolicySpec struct {
+	Tracepoints []TetragonTracepoint `json:"tracepoints"`
+}
+
+// TetragonTracepoint는 spec.tracepoints 항목
+type TetragonTracepoint struct {
+	Subsystem string             `json:"subsystem"`
+	Event     string             `json:"event"`
+	Args      []TetragonArg      `json:"args"`
+	Selectors []TetragonSelector `json:"selectors"`
+}
+
+// TetragonArg는 Tracepoint 인자 정의
+type TetragonArg struct {
+	Index int    `json:"index"`
+	Type  string `json:"type"`
+}
+
+// TetragonSelector는 selectors 항목
+type TetragonSelector struct {
+	MatchBinaries []TetragonMatch    `json:"matchBinaries,omitempty"`
+	MatchArgs     []TetragonArgMatch `json:"matchArgs,omitempty"`
+	MatchActions  []TetragonAction   `json:"matchActions,omitempty"`
+}
+
+// TetragonMatch는 matchBinaries 항목
+type TetragonMatch struct {
+	Operator string   `json:"operator"`
+	Values   []string `json:"values"`
+}
+
+// TetragonArgMatch는 matchArgs 항목
+type TetragonArgMatch struct {
+	Index    int      `json:"index"` // Tracepoint 의 args 목록 안의 위치 (TetragonArg.Index 가 아님)
+	Operator string   `json:"operator"`
+	Values   []string `json:"values"`
+}
+
+// TetragonAction은 matchActions 항목 (예: Post, Sigkill)
+type TetragonAction struct {
+	Action string `json:"action"`
+}
+
+// TetragonOptions는 TracingPolicy 생성 시 선택 가능한 값들
+type TetragonOptions struct {
+	Scope  PolicyScope
+	Arch   string // 대상 ELF 아키텍처, 비어 있으면 BitmapArch (시스템 콜 id 는 아키텍처별 번호)
+	Name   string // 비어 있으면 분석 대상 파일명으로 생성
+	Action string // 비어 있으면 Post (이벤트만 기록), "Sigkill" 이면 차단
+}
+
+// BuildTracingPolicy : {wrapper: kernelSyscall} 맵으로 허용 목록 밖(NotIn) 시스템 콜을 잡는 TracingPolicy 생성
+// 지원하지 않는 아키텍처면 오류, 번호를 알 수 없는 시스템 콜은 unknown 으로 반환
+func BuildTracingPolicy(targetPath string, syscallMap map[string]string, opts TetragonOptions) (TracingPolicy, []string, error) {
+	name := opts.Name
+	if name == "" {
+		name = ProfileName(targetPath)
+	}
+	action := opts.Action
+	if action == "" {
+		action = "Post"
+	}
+	binaryPath := opts.Scope.BinaryPath
+	if binaryPath == "" {
+		binaryPath = targetPath
+	}
+
+	// BPF 비트맵과 같은 번호 변환을 사용
+	arch := opts.Arch
+	if arch == "" {
+		arch = BitmapArch
+	}
+	bitmap, unknown, err := BuildSyscallBitmap(arch, syscallMap)
+	if err != nil {
+		return TracingPolicy{}, nil, err
+	}
+	ids := make([]string, 0, len(bitmap.Syscalls))
+	for _, s := range bitmap.Syscalls {
+		ids = append(ids, strconv.FormatInt(s.Number, 10))
+	}
+
+	meta := ObjectMeta{
+		Name: name,
+		Labels: map[string]string{
+			"app.kubernetes.io/managed-by": "elf-static-analyzer",
+		},
+		Annotations: map[string]string{
+			"elf-static-analyzer/target": targetPath,
+		},
+	}
+	if opts.Scope.Image != "" {
+		meta.Annotations["elf-static-analyzer/image"] = opts.Scope.Image
+	}
+
+	policy := TracingPolicy{
+		APIVersion: TracingPolicyAPIVersion,
+		Kind:       TracingPolicyKind,
+		Metadata:   meta,
+		Spec: TracingPolicySpec{
+			Tracepoints: []TetragonTracepoint{{
+				Subsystem: "raw_syscalls",
+				Event:     "sys_enter",
+				Args:      []TetragonArg{{Index: rawSyscallIDArgIndex, Type: "int64"}},
+				Selectors: []TetragonSelector{{
+					MatchBinaries: []TetragonMatch{{Operator: "In", Values: []string{binaryPath}}},
+					MatchArgs:     []TetragonArgMatch{{Index: syscallIDMatchIndex, Operator: "NotIn", Values: ids}},
+					MatchActions:  []TetragonAction{{Action: action}},
+				}},
+			}},
+		},
+	}
+	return policy, unknown, nil
+}
@@ -0,0 +1,76 @@
+package export
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TestBuildTracingPolicyArgIndex : matchArgs[0].index 는 정책 args 목록의 위치(0)이고,
+// raw_syscalls:sys_enter 의 id 필드 번호(4)는 args 에만 쓰이는지 직렬화된 매니페스트로 확인
+func TestBuildTracingPolicyArgIndex(t *testing.T) {
+	policy, unknown, err := BuildTracingPolicy("/bin/app", map[string]string{"read": "read", "write": "write"}, TetragonOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(unknown) != 0 {
+		t.Fatalf("unknown = %v", unknown)
+	}
+	data, err := json.Marshal(policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var doc struct {
+		Spec struct {
+			Tracepoints []struct {
+				Args []struct {
+					Index int    `json:"index"`
+					Type  string `json:"type"`
+				} `json:"args"`
+				Selectors []struct {
+					MatchArgs []struct {
+						Index    int      `json:"index"`
+						Operator string   `json:"operator"`
+						Values   []string `json:"values"`
+					} `json:"matchArgs"`
+					MatchActions []struct {
+						Action string `json:"action"`
+					} `json:"matchActions"`
+				} `json:"selectors"`
+			} `json:"tracepoints"`
+		} `json:"spec"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if len(doc.Spec.Tracepoints) != 1 {
+		t.Fatalf("tracepoints = %d, want 1", len(doc.Spec.Tracepoints))
+	}
+	tp := doc.Spec.Tracepoints[0]
+	if len(tp.Args) != 1 || tp.Args[0].Index != 4 || tp.Args[0].Type != "int64" {
+		t.Errorf("args = %+v, want [{index: 4, type: int64}]", tp.Args)
+	}
+	if len(tp.Selectors) != 1 || len(tp.Selectors[0].MatchArgs) != 1 {
+		t.Fatalf("selectors = %+v", tp.Selectors)
+	}
+	match := tp.Selectors[0].MatchArgs[0]
+	if match.Index != 0 {
+		t.Errorf("matchArgs[0].index = %d, want 0 (position in args)", match.Index)
+	}
+	if match.Index >= len(tp.Args) {
+		t.Errorf("matchArgs[0].index = %d points outside args (%d entries)", match.Index, len(tp.Args))
+	}
+	if match.Operator != "NotIn" || !sameStrings(match.Values, []string{"0", "1"}) {
+		t.Errorf("matchArgs[0] = %+v, want NotIn [0 1]", match)
+	}
+	if actions := tp.Selectors[0].MatchActions; len(actions) != 1 || actions[0].Action != "Post" {
+		t.Errorf("matchActions = %+v, want [Post]", actions)
+	}
+}
+
+// TestBuildTracingPolicyUnsupportedArch : x86_64 가 아닌 대상은 x86_64 번호로 정책을 만들지 않고 오류
+func TestBuildTracingPolicyUnsupportedArch(t *testing.T) {
+	if _, _, err := BuildTracingPolicy("/bin/app", map[string]string{"read": "read"}, TetragonOptions{Arch: "EM_AARCH64"}); err == nil {
+		t.Error("BuildTracingPolicy(EM_AARCH64) = nil error, want unsupported arch")
+	}
+}
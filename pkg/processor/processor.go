@@ -1,96 +1,373 @@
 package processor
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"ips_bpf/static-analyzer/pkg/analyzer"
+	"ips_bpf/static-analyzer/pkg/asmanalysis"
 	"ips_bpf/static-analyzer/pkg/syscalls" // [신규] syscalls 패키지 임포트
 	"log"
+	"os"
+	"runtime"
+	"sort"
 	"strings"
+	"sync"
 )
 
-// BuildSyscallMap은 Libc 분석기와 래퍼 목록을 받아
-// 최종적인 {wrapper: kernelSyscall} 맵을 생성합니다.
-func BuildSyscallMap(libcAnalyzer *analyzer.ELFAnalyzer, uniqueWrappers map[string]struct{}) map[string]string {
+// Options는 BuildSyscallMap 설정
+type Options struct {
+	// Concurrency : 동시에 추적할 래퍼 수 (0 이하면 CPU 수)
+	Concurrency int
+	// LibcTable : libc 심볼별 추적 결과 캐시 (nil이면 사용하지 않음)
+	// 표에 있는 래퍼는 역어셈 없이 재사용하고, 새로 추적한 래퍼는 결과(찾지 못하면 "")를 표에 기록합니다.
+	LibcTable map[string]string
+	// LibcReasons : LibcTable에 ""로 기록된 심볼의 Reason 코드 (nil이면 기록하지 않음)
+	LibcReasons map[string]string
+	// LibcConfidence : LibcTable에 시스템 콜이 기록된 심볼의 신뢰도 이름 (nil이면 기록하지 않음)
+	LibcConfidence map[string]string
+	// MinConfidence : 이보다 신뢰도가 낮은 래퍼는 Wrappers(허용 목록)에서 제외 (0이면 모두 포함)
+	MinConfidence asmanalysis.Confidence
+}
 
-	// [이동] main.go에서 이동
-	redisMap := make(map[string]string) // Redis K-V 포맷용 맵
+// Result는 BuildSyscallMap 결과
+type Result struct {
+	Wrappers   map[string]string // {wrapper: kernelSyscall}
+	Traces     map[string]*Trace // 추적을 마친 모든 래퍼의 상세 (시스템 콜을 찾지 못했거나 Tracepoint가 없는 래퍼 포함)
+	Incomplete bool              // 시간 초과/취소로 일부 래퍼를 추적하지 못함 (Wrappers는 부분 결과)
+	Unfinished []string          // 추적하지 못한 래퍼 (이름 순)
+	Err        error             // Incomplete의 원인 (ctx.Err())
+}
 
-	// [이동] main.go의 for 루프 전체
-	for wrapperName := range uniqueWrappers {
-		if wrapperName == "" {
-			continue
-		}
+// Trace는 래퍼 하나의 추적 상세 (보고서용)
+type Trace struct {
+	Symbol     string                    // 시스템 콜을 찾은 libc 심볼 ("64" 접미사로 재시도했다면 "<wrapper>64")
+	Address    uint64                    // Symbol의 주소 (IFUNC면 리졸버 주소, 심볼이 없거나 정의되지 않았으면 0)
+	Syscall    string                    // 커널 시스템 콜 이름 ("" = 찾지 못함)
+	Candidates []asmanalysis.SyscallInfo // Symbol에서 발견한 syscall 명령어 (주소, rax 값)
+	FromTable  bool                      // libc 표에서 재사용한 결과 (Candidates 없음)
+	Confidence asmanalysis.Confidence    // Syscall의 신뢰도 (재시도/간접 추적이면 그 단계의 등급으로 낮춤)
+	Tracepoint bool                      // Syscall의 Tracepoint 존재 여부
+	Allowed    bool                      // Wrappers(허용 목록)에 포함됨 (시스템 콜 + Tracepoint + MinConfidence 이상)
+	Reason     Reason                    // 허용 목록에 포함되지 않은 이유
+	Detail     string                    // Reason의 부가 설명 (오류 메시지 등)
+}
 
-		// FindKernelSyscallPatterns (복수형) 호출
-		syscallPatterns, err := libcAnalyzer.FindKernelSyscallPatterns(wrapperName)
+// Reason은 래퍼를 허용 목록에 넣지 못한 이유 (보고서의 reason 코드, 이미지 전체의 분석 커버리지 집계용)
+type Reason string
 
-		if err != nil {
-			// 1. 심볼 자체를 찾는 데 실패한 경우 (예: "fstat"이 아예 없음)
-			log.Printf("  [경고] '%s' 래퍼 추적 실패: %v\n", wrapperName, err)
-			continue // 다음 래퍼로
+const (
+	ReasonSymbolNotFound Reason = "symbol-not-found"     // libc에 래퍼 심볼이 없음
+	ReasonNoSyscall      Reason = "no-syscall-in-body"   // 심볼 본문에 syscall 명령어가 없음 (다른 함수로 jmp/call 하는 래퍼 등)
+	ReasonRaxUnknown     Reason = "rax-unknown"          // syscall 명령어는 있지만 rax 값을 추적하지 못함
+	ReasonNotInTable     Reason = "number-not-in-table"  // rax 값이 x86_64 시스템 콜 표에 없음
+	ReasonNoTracepoint   Reason = "no-tracepoint"        // 시스템 콜은 찾았지만 Tracepoint가 없어 허용 목록에서 제외
+	ReasonLowConfidence  Reason = "below-min-confidence" // 시스템 콜의 신뢰도가 MinConfidence 보다 낮아 허용 목록에서 제외
+	ReasonIFunc          Reason = "ifunc"                // IFUNC 심볼 (구현을 실행 시 고르므로 정적으로 추적하지 않음)
+	ReasonPLTOnly        Reason = "plt-only"             // libc에 정의되지 않은 심볼 (다른 라이브러리의 PLT로만 호출)
+	ReasonUnknown        Reason = "unknown"              // 이유를 기록하지 않은 이전 libc 표의 결과
+	ReasonTraceFailed    Reason = "trace-failed"         // 그 외 추적 오류 (역어셈 실패 등)
+	ReasonDeadImport     Reason = "dead-import"          // 대상 바이너리의 진입점에서 도달 가능한 호출 지점이 없음 (--drop-dead-imports, 기본값)
+)
+
+// BuildSyscallMap은 Libc 분석기와 래퍼 목록을 받아
+// 최종적인 {wrapper: kernelSyscall} 맵을 생성합니다.
+// [신규] 래퍼 추적은 opts.Concurrency 개의 고루틴이 나누어 수행하고(Capstone 엔진은 고루틴마다 따로 사용),
+// 로그 출력과 결과 병합은 래퍼 이름 순으로 하므로 동시 실행 수와 관계없이 같은 결과가 나옵니다.
+// [신규] ctx가 취소되거나 기한이 지나면 남은 래퍼는 추적하지 않고, 그때까지의 매핑을 Incomplete 표시와 함께 반환합니다.
+// 추적하지 못한 래퍼는 libcTable에 기록하지 않습니다.
+func BuildSyscallMap(ctx context.Context, libcAnalyzer *analyzer.ELFAnalyzer, uniqueWrappers map[string]struct{}, opts Options) *Result {
+	names := make([]string, 0, len(uniqueWrappers))
+	for wrapperName := range uniqueWrappers {
+		if wrapperName != "" {
+			names = append(names, wrapperName)
 		}
+	}
+	sort.Strings(names)
 
-		// 2. 래퍼에서 유효한 커널 시스템 콜 이름 찾기
-		foundKernelName := ""
-		if len(syscallPatterns) > 0 {
-			fmt.Printf("  [성공] '%s' 래퍼에서 %d개의 'syscall' 패턴 발견:\n", wrapperName, len(syscallPatterns))
-
-			for _, pattern := range syscallPatterns {
-				fmt.Printf("    - 주소: 0x%x $\to$ 커널 Syscall #%d (0x%x)\n", pattern.Address, pattern.Number, pattern.Number)
-				// 첫 번째로 유효한(-1이 아닌) 시스템 콜 번호를 찾으면 이름으로 변환
-				if foundKernelName == "" && pattern.Number != -1 {
-					// [수정] analyzer. -> syscalls.
-					if name, ok := syscalls.GetKernelSyscallName(pattern.Number); ok {
-						foundKernelName = name
-					}
-				}
+	// 1. 표에 없는 래퍼만 작업자 풀에서 추적
+	var pending []int
+	for i, wrapperName := range names {
+		if _, cached := opts.LibcTable[wrapperName]; !cached {
+			pending = append(pending, i)
+		}
+	}
+	traced := make([]*traceResult, len(names))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workerCount(opts.Concurrency, len(pending)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				traced[i] = traceWrapper(ctx, libcAnalyzer, names[i])
 			}
+		}()
+	}
+dispatch:
+	for _, i := range pending {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break dispatch
 		}
+	}
+	close(jobs)
+	wg.Wait()
 
-		// 3. 첫 번째 시도 실패 및 "64" 접미사로 재시도
-		if foundKernelName == "" {
-			if len(syscallPatterns) == 0 {
-				log.Printf("  [정보] '%s' 래퍼에서 'syscall' 명령어를 찾지 못함 (JMP 추적 필요할 수 있음)\n", wrapperName)
-			} else {
-				log.Printf("  [정보] '%s' 래퍼에서 유효한 커널 시스템 콜 번호를 찾지 못함 (모두 -1 이었음)\n", wrapperName)
+	// 2. 이름 순으로 로그 출력, 표 기록 및 최종 맵에 저장
+	result := &Result{
+		Wrappers: make(map[string]string), // Redis K-V 포맷용 맵
+		Traces:   make(map[string]*Trace),
+	}
+	for i, wrapperName := range names {
+		foundKernelName, cached := opts.LibcTable[wrapperName]
+		trace := &Trace{Symbol: wrapperName, Syscall: foundKernelName, FromTable: true}
+		if cached {
+			if sym, ok := libcAnalyzer.LookupSymbol(wrapperName); ok {
+				trace.Address = sym.Value
 			}
-
-			// "64" 접미사 재시도 로직
-			if !strings.HasSuffix(wrapperName, "64") {
-				newName := wrapperName + "64"
-				log.Printf("  [시도] '%s'로 재시도...\n", newName)
-
-				syscallPatterns, err = libcAnalyzer.FindKernelSyscallPatterns(newName)
-
-				if err == nil && len(syscallPatterns) > 0 {
-					fmt.Printf("  [성공] '%s' (%s) 래퍼에서 %d개의 'syscall' 패턴 발견:\n", newName, wrapperName, len(syscallPatterns))
-					for _, pattern := range syscallPatterns {
-						fmt.Printf("    - 주소: 0x%x $\to$ 커널 Syscall #%d (0x%x)\n", pattern.Address, pattern.Number, pattern.Number)
-						if foundKernelName == "" && pattern.Number != -1 {
-							// [수정] analyzer. -> syscalls.
-							if name, ok := syscalls.GetKernelSyscallName(pattern.Number); ok {
-								foundKernelName = name
-							}
-						}
-					}
-				} else {
-					log.Printf("  [실패] '%s' 재시도 실패 (오류: %v, 패턴: %d개)\n", newName, err, len(syscallPatterns))
+			if foundKernelName == "" {
+				trace.Reason = Reason(opts.LibcReasons[wrapperName])
+				if trace.Reason == "" {
+					trace.Reason = ReasonUnknown
 				}
+			} else {
+				confidence, err := asmanalysis.ParseConfidence(opts.LibcConfidence[wrapperName])
+				if err != nil {
+					// 신뢰도를 기록하기 전의 libc 표: 알 수 없으므로 가장 약한 등급으로 보고 --min-confidence 로 걸러지게 함
+					confidence = asmanalysis.ConfidenceTransitive
+				}
+				trace.Confidence = confidence
+			}
+		} else {
+			r := traced[i]
+			if r == nil || r.cancelled {
+				result.Unfinished = append(result.Unfinished, wrapperName) // 취소되어 추적하지 못한 래퍼
+				continue
+			}
+			r.log.flush()
+			trace = &r.trace
+			foundKernelName = trace.Syscall
+			if opts.LibcTable != nil {
+				opts.LibcTable[wrapperName] = foundKernelName
+			}
+			if opts.LibcReasons != nil && foundKernelName == "" {
+				opts.LibcReasons[wrapperName] = string(trace.Reason)
+			}
+			if opts.LibcConfidence != nil && foundKernelName != "" {
+				opts.LibcConfidence[wrapperName] = trace.Confidence.String()
 			}
 		}
+		result.Traces[wrapperName] = trace
 
 		// 4. [수정] 최종 맵에 저장 (Tracepoint 필터링 포함)
 		if foundKernelName != "" {
 			// [신규] 커널 시스템 콜 이름으로 Tracepoint 존재 여부 확인
 			// [수정] analyzer. -> syscalls.
-			if syscalls.IsTracepointAvailable(foundKernelName) {
-				redisMap[wrapperName] = foundKernelName
-				log.Printf("  [매핑] %s $\to$ %s (Tracepoint: ✓)\n", wrapperName, foundKernelName)
-			} else {
+			trace.Tracepoint = syscalls.IsTracepointAvailable(foundKernelName)
+			switch {
+			case !trace.Tracepoint:
+				trace.Reason = ReasonNoTracepoint
 				log.Printf("  [정보] %s $\to$ %s (Tracepoint: ✗ - 필터링됨)\n", wrapperName, foundKernelName)
+			case trace.Confidence < opts.MinConfidence:
+				// [신규] 최소 신뢰도 미만은 허용 목록에서 제외 (보고서에는 남김)
+				trace.Reason = ReasonLowConfidence
+				log.Printf("  [정보] %s $\to$ %s (신뢰도: %s < %s - 필터링됨)\n", wrapperName, foundKernelName, trace.Confidence, opts.MinConfidence)
+			default:
+				trace.Allowed = true
+				result.Wrappers[wrapperName] = foundKernelName
+				log.Printf("  [매핑] %s $\to$ %s (Tracepoint: ✓, 신뢰도: %s)\n", wrapperName, foundKernelName, trace.Confidence)
 			}
 		}
 	}
 
-	return redisMap
+	if len(result.Unfinished) > 0 {
+		result.Incomplete = true
+		result.Err = ctx.Err()
+	}
+	return result
+}
+
+// workerCount : 작업자 고루틴 수 (요청 수, CPU 수, 남은 작업 수 중 작은 값)
+func workerCount(concurrency, jobs int) int {
+	if concurrency <= 0 {
+		concurrency = runtime.NumCPU()
+	}
+	if concurrency > jobs {
+		concurrency = jobs
+	}
+	return concurrency
+}
+
+// traceResult : 래퍼 하나의 추적 결과와 출력을 미룬 로그
+type traceResult struct {
+	trace     Trace
+	cancelled bool // 추적 도중 ctx가 취소됨 (결과를 쓰지 않음)
+	log       traceLog
+}
+
+// traceLog : 동시에 추적하는 래퍼들의 로그가 섞이지 않도록 모아 두었다가 래퍼 순서대로 출력
+// Printf는 표준 에러(fmt.Fprintf), Logf는 log.Printf 로 출력됨 (표준 출력은 결과 전용)
+type traceLog []traceLine
+
+type traceLine struct {
+	toLog bool
+	text  string
+}
+
+func (l *traceLog) Printf(format string, args ...interface{}) {
+	*l = append(*l, traceLine{text: fmt.Sprintf(format, args...)})
+}
+
+func (l *traceLog) Logf(format string, args ...interface{}) {
+	*l = append(*l, traceLine{toLog: true, text: fmt.Sprintf(format, args...)})
+}
+
+func (l traceLog) flush() {
+	for _, line := range l {
+		if line.toLog {
+			log.Print(line.text)
+		} else {
+			fmt.Fprint(os.Stderr, line.text)
+		}
+	}
+}
+
+// traceWrapper : libc에서 래퍼 하나를 역어셈하여 커널 시스템 콜 이름을 찾음 (찾지 못하면 "", 이유는 trace.Reason)
+// 여러 고루틴에서 동시에 호출되므로 로그는 결과에 모아서 반환
+func traceWrapper(ctx context.Context, libcAnalyzer *analyzer.ELFAnalyzer, wrapperName string) *traceResult {
+	r := &traceResult{}
+	// FindKernelSyscallPatterns (복수형) 호출
+	syscallPatterns, err := libcAnalyzer.FindKernelSyscallPatterns(ctx, wrapperName)
+
+	if ctx.Err() != nil {
+		r.cancelled = true
+		return r
+	}
+	r.trace = newTrace(libcAnalyzer, wrapperName, syscallPatterns, err)
+	if err != nil {
+		// 1. 심볼 자체를 찾는 데 실패한 경우 (예: "fstat"이 아예 없음, 정의되지 않은 심볼, IFUNC)
+		r.log.Logf("  [경고] '%s' 래퍼 추적 실패 (%s): %v\n", wrapperName, r.trace.Reason, err)
+		return r
+	}
+
+	// 2. 래퍼에서 유효한 커널 시스템 콜 이름 찾기
+	if len(syscallPatterns) > 0 {
+		r.log.Printf("  [성공] '%s' 래퍼에서 %d개의 'syscall' 패턴 발견:\n", wrapperName, len(syscallPatterns))
+		for _, pattern := range syscallPatterns {
+			r.log.Printf("    - 주소: 0x%x $\to$ 커널 Syscall #%d (0x%x)\n", pattern.Address, pattern.Number, pattern.Number)
+		}
+	}
+
+	// 3. 첫 번째 시도 실패 및 "64" 접미사로 재시도
+	if r.trace.Syscall == "" {
+		r.log.Logf("  [정보] '%s' 래퍼에서 커널 시스템 콜을 찾지 못함 (%s)\n", wrapperName, r.trace.Reason)
+
+		// "64" 접미사 재시도 로직 (재시도도 실패하면 원래 래퍼의 이유를 유지)
+		if !strings.HasSuffix(wrapperName, "64") {
+			newName := wrapperName + "64"
+			r.log.Logf("  [시도] '%s'로 재시도...\n", newName)
+
+			syscallPatterns, err = libcAnalyzer.FindKernelSyscallPatterns(ctx, newName)
+			if ctx.Err() != nil {
+				r.cancelled = true
+				return r
+			}
+
+			if err == nil && len(syscallPatterns) > 0 {
+				r.log.Printf("  [성공] '%s' (%s) 래퍼에서 %d개의 'syscall' 패턴 발견:\n", newName, wrapperName, len(syscallPatterns))
+				for _, pattern := range syscallPatterns {
+					r.log.Printf("    - 주소: 0x%x $\to$ 커널 Syscall #%d (0x%x)\n", pattern.Address, pattern.Number, pattern.Number)
+				}
+				if retry := newTrace(libcAnalyzer, newName, syscallPatterns, nil); retry.Syscall != "" {
+					retry.Confidence = retry.Confidence.Min(asmanalysis.ConfidenceHeuristicAlias)
+					r.trace = retry
+				}
+			} else {
+				r.log.Logf("  [실패] '%s' 재시도 실패 (오류: %v, 패턴: %d개)\n", newName, err, len(syscallPatterns))
+			}
+		}
+	}
+
+	// 4. [신규] 래퍼 본문이 직접 jmp/call 하는 다른 export 함수를 한 단계만 추적 (예: 다른 래퍼로 꼬리 호출)
+	if r.trace.Syscall == "" && r.trace.Reason == ReasonNoSyscall {
+		if !traceCallees(ctx, libcAnalyzer, wrapperName, r) {
+			r.cancelled = true
+		}
+	}
+	return r
+}
+
+// traceCallees : 래퍼가 직접 호출하는 함수에서 처음으로 시스템 콜을 찾으면 r.trace를 그 결과(ConfidenceTransitive)로 바꿈
+// ctx가 취소되면 false
+func traceCallees(ctx context.Context, libcAnalyzer *analyzer.ELFAnalyzer, wrapperName string, r *traceResult) bool {
+	callees, err := libcAnalyzer.DirectCallees(ctx, wrapperName)
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil || len(callees) == 0 {
+		return true
+	}
+
+	for _, callee := range callees {
+		patterns, err := libcAnalyzer.FindKernelSyscallPatterns(ctx, callee)
+		if ctx.Err() != nil {
+			return false
+		}
+		if err != nil {
+			continue
+		}
+		if t := newTrace(libcAnalyzer, callee, patterns, nil); t.Syscall != "" {
+			t.Confidence = asmanalysis.ConfidenceTransitive
+			r.trace = t
+			r.log.Printf("  [성공] '%s' 래퍼가 호출하는 '%s'에서 커널 시스템 콜 %s 발견 (transitive)\n", wrapperName, callee, t.Syscall)
+			return true
+		}
+	}
+	r.log.Logf("  [정보] '%s' 래퍼가 호출하는 함수 %d개에서도 커널 시스템 콜을 찾지 못함\n", wrapperName, len(callees))
+	return true
+}
+
+// newTrace : 심볼 하나의 FindKernelSyscallPatterns 결과로 Trace를 만듦
+// 첫 번째로 유효한(-1이 아니고 시스템 콜 표에 있는) 번호를 커널 시스템 콜로 선택하고, 없으면 Reason을 채움
+func newTrace(libcAnalyzer *analyzer.ELFAnalyzer, symbol string, patterns []asmanalysis.SyscallInfo, err error) Trace {
+	t := Trace{Symbol: symbol, Candidates: patterns}
+	if sym, ok := libcAnalyzer.LookupSymbol(symbol); ok {
+		t.Address = sym.Value
+	}
+
+	switch {
+	case errors.Is(err, analyzer.ErrSymbolNotFound):
+		t.Reason = ReasonSymbolNotFound
+	case errors.Is(err, analyzer.ErrPLTOnly):
+		t.Reason = ReasonPLTOnly
+	case errors.Is(err, analyzer.ErrIFunc):
+		t.Reason = ReasonIFunc
+	case err != nil:
+		t.Reason = ReasonTraceFailed
+	}
+	if err != nil {
+		t.Detail = err.Error()
+		return t
+	}
+	if len(patterns) == 0 {
+		t.Reason = ReasonNoSyscall
+		return t
+	}
+
+	t.Reason = ReasonRaxUnknown
+	for _, pattern := range patterns {
+		if pattern.Number == -1 {
+			continue
+		}
+		// [수정] analyzer. -> syscalls.
+		if name, ok := syscalls.GetKernelSyscallName(pattern.Number); ok {
+			t.Syscall, t.Confidence, t.Reason = name, pattern.Confidence, ""
+			return t
+		}
+		t.Reason = ReasonNotInTable
+		t.Detail = fmt.Sprintf("0x%x: rax=%d", pattern.Address, pattern.Number)
+	}
+	return t
 }
@@ -0,0 +1,440 @@
+// pkg/report/report.go
+package report
+
+import (
+	"fmt"
+	"ips_bpf/static-analyzer/pkg/analyzer"
+	"ips_bpf/static-analyzer/pkg/asmanalysis"
+	"ips_bpf/static-analyzer/pkg/export"
+	"ips_bpf/static-analyzer/pkg/processor"
+	"ips_bpf/static-analyzer/pkg/storage"
+	"ips_bpf/static-analyzer/pkg/syscalls"
+	"sort"
+	"time"
+)
+
+// SchemaVersion : --format json 보고서 스키마 버전 (필드를 지우거나 의미를 바꾸면 올림, 필드 추가는 그대로)
+const SchemaVersion = 2
+
+// Report는 --format json 의 최상위 구조체 (표준 출력에는 이 JSON만 기록됨)
+type Report struct {
+	SchemaVersion   int          `json:"schema_version"`
+	AnalyzerVersion string       `json:"analyzer_version"`
+	AnalyzedAt      time.Time    `json:"analyzed_at"`
+	Target          Binary       `json:"target"`
+	Libc            Binary       `json:"libc"`
+	MinConfidence   string       `json:"min_confidence,omitempty"` // 허용 목록에 넣은 최소 신뢰도 (--min-confidence)
+	Cached          bool         `json:"cached"`                   // 저장된 결과(캐시)를 사용함 (래퍼별 후보/미해결 상세 없음)
+	Incomplete      bool         `json:"incomplete"`               // 시간 초과/취소로 일부 래퍼를 추적하지 못함
+	Unfinished      []string     `json:"unfinished,omitempty"`     // 추적하지 못한 래퍼
+	Wrappers        []Wrapper    `json:"wrappers"`                 // 커널 시스템 콜을 찾은 래퍼 (이름 순, 허용 목록에서 빠진 래퍼 포함, 도달 불가능한 래퍼 제외)
+	Unreachable     []Wrapper    `json:"unreachable"`              // 커널 시스템 콜을 찾았지만 진입점에서 도달 가능한 호출 지점이 없는 래퍼 (SeparateUnreachable)
+	Unresolved      []Unresolved `json:"unresolved"`               // 커널 시스템 콜을 찾지 못한 래퍼 (이름 순)
+	Syscalls        []string     `json:"syscalls"`                 // 허용 목록 (allowed 래퍼의 시스템 콜, 정렬)
+	Coverage        Coverage     `json:"coverage"`
+	Arguments       []Argument   `json:"arguments,omitempty"` // 대상 바이너리의 호출 지점에서 복원한 래퍼 인자 상수 (래퍼 이름 순)
+}
+
+// Coverage는 래퍼 추적 커버리지 요약 (이미지 전체의 분석기 커버리지 집계용)
+type Coverage struct {
+	Wrappers int            `json:"wrappers"` // 추적을 마친 래퍼 수 (Unfinished 제외)
+	Allowed  int            `json:"allowed"`  // 허용 목록에 들어간 래퍼 수 (시스템 콜 + Tracepoint + 최소 신뢰도)
+	Reasons  map[string]int `json:"reasons"`  // 허용 목록에 들어가지 못한 래퍼의 reason 코드별 수 (processor.Reason)
+}
+
+// Binary는 분석 대상 또는 libc의 식별 정보
+type Binary struct {
+	Path    string `json:"path"`
+	SHA256  string `json:"sha256"`
+	BuildID string `json:"build_id,omitempty"`
+	Arch    string `json:"arch"`
+}
+
+// Wrapper는 커널 시스템 콜을 찾은 래퍼 하나
+type Wrapper struct {
+	Name       string      `json:"name"`
+	Symbol     string      `json:"symbol"`               // 시스템 콜을 찾은 libc 심볼 (예: stat -> stat64)
+	Syscall    string      `json:"syscall"`              // 커널 시스템 콜 이름
+	Number     int64       `json:"number"`               // x86_64 시스템 콜 번호 (모르면 -1)
+	Address    string      `json:"address,omitempty"`    // Symbol의 주소 ("0x...", libc 기준)
+	Confidence string      `json:"confidence,omitempty"` // exact, propagated, heuristic-alias, transitive (asmanalysis.Confidence)
+	Tracepoint bool        `json:"tracepoint"`
+	Allowed    bool        `json:"allowed"`          // 허용 목록(syscalls)에 포함됨
+	Reason     string      `json:"reason,omitempty"` // 허용 목록에서 빠진 이유 (no-tracepoint, below-min-confidence)
+	Source     string      `json:"source"`           // 결과 출처 (SourceTraced, SourceLibcTable, SourceCache)
+	Candidates []Candidate `json:"candidates,omitempty"`
+	CallSites  []CallSite  `json:"call_sites,omitempty"`  // 대상 바이너리에서 이 래퍼를 호출하는 지점 (주소 순)
+	DeadImport bool        `json:"dead_import,omitempty"` // 가져오기만 하고 진입점에서 도달 가능한 호출 지점이 없음
+}
+
+// Unresolved는 커널 시스템 콜을 찾지 못한 래퍼 하나
+type Unresolved struct {
+	Name       string      `json:"name"`
+	Symbol     string      `json:"symbol"`
+	Address    string      `json:"address,omitempty"` // 추적한 심볼의 주소 (IFUNC면 리졸버 주소)
+	Reason     string      `json:"reason"`            // reason 코드 (processor.Reason)
+	Detail     string      `json:"detail,omitempty"`  // 오류 메시지, 표에 없는 rax 값 등
+	Source     string      `json:"source"`
+	Candidates []Candidate `json:"candidates,omitempty"`
+	CallSites  []CallSite  `json:"call_sites,omitempty"`
+	DeadImport bool        `json:"dead_import,omitempty"`
+}
+
+// Candidate는 래퍼 본문에서 발견한 syscall 명령어 하나
+type Candidate struct {
+	Address    string `json:"address"`              // syscall 명령어 주소 ("0x...", libc 기준)
+	Number     int64  `json:"number"`               // 호출 시점의 rax 값 (-1 = 알 수 없음)
+	Syscall    string `json:"syscall,omitempty"`    // Number의 커널 시스템 콜 이름
+	Confidence string `json:"confidence,omitempty"` // rax 값의 신뢰도 (exact, propagated)
+}
+
+// Argument는 래퍼 하나의 호출 지점별 인자 값 (seccomp 인자 필터, eBPF 인자 검사 입력)
+type Argument struct {
+	Wrapper   string     `json:"wrapper"`
+	Syscall   string     `json:"syscall,omitempty"` // 래퍼의 커널 시스템 콜 (허용 목록에 없으면 "")
+	Params    []Param    `json:"params"`            // syscalls.GetArgSpecs 의 인자별 값 요약
+	CallSites []CallSite `json:"call_sites"`        // 주소 순
+}
+
+// Param은 인자 하나에 대해 호출 지점에서 관찰한 상수 값 모음
+type Param struct {
+	Name       string       `json:"name"`
+	Register   string       `json:"register"`    // 래퍼 호출 시 레지스터 (rdi, rsi, rdx, rcx)
+	SyscallArg int          `json:"syscall_arg"` // 커널 시스템 콜 인자 순서 (seccomp_data.args 인덱스)
+	Values     []ParamValue `json:"values"`      // 상수로 확인된 값 (값 순, 중복 제거)
+	Unknown    int          `json:"unknown"`     // 값을 복원하지 못한 호출 지점 수 (0이 아니면 값 목록이 전부가 아님)
+}
+
+// ParamValue는 인자의 상수 값 하나
+type ParamValue struct {
+	Value string `json:"value"`          // "0x..."
+	Name  string `json:"name,omitempty"` // 상수 이름 (예: AF_INET, SOCK_STREAM|SOCK_CLOEXEC)
+}
+
+// CallSite는 대상 바이너리의 래퍼 호출 지점 하나
+type CallSite struct {
+	Address      string            `json:"address"`                 // call/jmp 명령어 주소 ("0x...", 대상 바이너리 기준)
+	Function     string            `json:"function,omitempty"`      // 호출한 함수 (.symtab, 스트립된 바이너리는 "가까운 export 함수+0x오프셋")
+	Tail         bool              `json:"tail,omitempty"`          // 꼬리 호출 (jmp)
+	Indirect     bool              `json:"indirect,omitempty"`      // GOT 엔트리를 거친 호출 (call [rip + X], -fno-plt)
+	AddressTaken bool              `json:"address_taken,omitempty"` // 호출이 아니라 주소만 읽음 (함수 포인터)
+	DataRef      bool              `json:"data_ref,omitempty"`      // 데이터 재배치(R_X86_64_64 등)에 저장된 주소 (function 은 섹션 이름, 항상 도달 가능으로 봄)
+	Unreachable  bool              `json:"unreachable,omitempty"`   // 호출한 함수가 진입점(main, _start, 생성자, export 함수)에서 도달 불가
+	Args         map[string]string `json:"args,omitempty"`          // 상수로 확인된 인자 레지스터 값 {rdi: "0x2"} (arguments 에서만)
+}
+
+// 래퍼 결과 출처
+const (
+	SourceTraced    = "traced"     // 이번 실행에서 libc를 역어셈하여 추적
+	SourceLibcTable = "libc-table" // libc 심볼별 시스템 콜 표에서 재사용
+	SourceCache     = "cache"      // 저장된 분석 결과(캐시)에서 읽음
+)
+
+// New : 식별 정보만 채운 빈 보고서 생성 (meta.AnalyzedAt 이 비어 있으면 현재 시각)
+func New(meta storage.Meta, targetArch, libcArch string) *Report {
+	analyzedAt := meta.AnalyzedAt
+	if analyzedAt.IsZero() {
+		analyzedAt = time.Now().UTC()
+	}
+	return &Report{
+		SchemaVersion:   SchemaVersion,
+		AnalyzerVersion: meta.AnalyzerVersion,
+		AnalyzedAt:      analyzedAt,
+		MinConfidence:   meta.MinConfidence,
+		Target:          Binary{Path: meta.Path, SHA256: meta.SHA256, BuildID: meta.BuildID, Arch: targetArch},
+		Libc:            Binary{Path: meta.LibcPath, SHA256: meta.LibcSHA256, BuildID: meta.LibcBuildID, Arch: libcArch},
+		Wrappers:        []Wrapper{},
+		Unreachable:     []Wrapper{},
+		Unresolved:      []Unresolved{},
+		Syscalls:        []string{},
+		Coverage:        Coverage{Reasons: map[string]int{}},
+	}
+}
+
+// AddResult : BuildSyscallMap 결과의 래퍼별 추적 상세를 보고서에 채움
+func (r *Report) AddResult(res *processor.Result) {
+	names := make([]string, 0, len(res.Traces))
+	for name := range res.Traces {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		t := res.Traces[name]
+		source := SourceTraced
+		if t.FromTable {
+			source = SourceLibcTable
+		}
+		candidates := convertCandidates(t)
+		r.Coverage.Wrappers++
+		if t.Reason != "" {
+			r.Coverage.Reasons[string(t.Reason)]++
+		}
+		if t.Syscall == "" {
+			r.Unresolved = append(r.Unresolved, Unresolved{
+				Name:       name,
+				Symbol:     t.Symbol,
+				Address:    hexAddress(t.Address),
+				Reason:     string(t.Reason),
+				Detail:     t.Detail,
+				Source:     source,
+				Candidates: candidates,
+			})
+			continue
+		}
+		if t.Allowed {
+			r.Coverage.Allowed++
+		}
+		r.Wrappers = append(r.Wrappers, Wrapper{
+			Name:       name,
+			Symbol:     t.Symbol,
+			Syscall:    t.Syscall,
+			Number:     syscallNumber(t.Syscall),
+			Address:    hexAddress(t.Address),
+			Confidence: t.Confidence.String(),
+			Tracepoint: t.Tracepoint,
+			Allowed:    t.Allowed,
+			Reason:     string(t.Reason),
+			Source:     source,
+			Candidates: candidates,
+		})
+	}
+	r.Incomplete = res.Incomplete
+	r.Unfinished = res.Unfinished
+	r.Syscalls = export.AllowList(r.SyscallMap())
+}
+
+// ReasonCount는 reason 코드 하나의 래퍼 수와 래퍼 이름
+type ReasonCount struct {
+	Reason   string
+	Wrappers []string
+}
+
+// ReasonSummary : reason 코드별 래퍼 목록 (래퍼 수가 많은 순, 같으면 코드 이름 순) - 로그 요약 표용
+func (r *Report) ReasonSummary() []ReasonCount {
+	byReason := make(map[string][]string)
+	for _, w := range append(append([]Wrapper(nil), r.Wrappers...), r.Unreachable...) {
+		if w.Reason != "" {
+			byReason[w.Reason] = append(byReason[w.Reason], w.Name)
+		}
+	}
+	for _, u := range r.Unresolved {
+		byReason[u.Reason] = append(byReason[u.Reason], u.Name)
+	}
+
+	summary := make([]ReasonCount, 0, len(byReason))
+	for reason, names := range byReason {
+		summary = append(summary, ReasonCount{Reason: reason, Wrappers: names})
+	}
+	sort.Slice(summary, func(i, j int) bool {
+		if len(summary[i].Wrappers) != len(summary[j].Wrappers) {
+			return len(summary[i].Wrappers) > len(summary[j].Wrappers)
+		}
+		return summary[i].Reason < summary[j].Reason
+	})
+	return summary
+}
+
+// AddCached : 저장된 {wrapper: kernelSyscall} 맵과 신뢰도로 보고서를 채움 (저장된 맵은 허용 목록 필터링을 거친 결과)
+func (r *Report) AddCached(wrappers, confidence map[string]string) {
+	names := make([]string, 0, len(wrappers))
+	for name := range wrappers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	r.Cached = true
+	r.Coverage.Wrappers, r.Coverage.Allowed = len(names), len(names)
+	for _, name := range names {
+		r.Wrappers = append(r.Wrappers, Wrapper{
+			Name:       name,
+			Symbol:     name,
+			Syscall:    wrappers[name],
+			Number:     syscallNumber(wrappers[name]),
+			Confidence: confidence[name],
+			Tracepoint: true,
+			Allowed:    true,
+			Source:     SourceCache,
+		})
+	}
+	r.Syscalls = export.AllowList(r.SyscallMap())
+}
+
+// SyscallMap : 허용 목록에 들어간 래퍼의 {wrapper: kernelSyscall} 맵 (Redis K-V, 다른 출력 형식의 입력)
+func (r *Report) SyscallMap() map[string]string {
+	m := make(map[string]string, len(r.Wrappers))
+	for _, w := range r.Wrappers {
+		if w.Allowed {
+			m[w.Name] = w.Syscall
+		}
+	}
+	return m
+}
+
+// ConfidenceMap : 허용 목록에 들어간 래퍼의 {wrapper: 신뢰도} 맵 (결과 저장용)
+func (r *Report) ConfidenceMap() map[string]string {
+	m := make(map[string]string, len(r.Wrappers))
+	for _, w := range r.Wrappers {
+		if w.Allowed && w.Confidence != "" {
+			m[w.Name] = w.Confidence
+		}
+	}
+	return m
+}
+
+// AddCallSites : 래퍼별 호출 지점(analyzer.ImportCallSites 결과)을 wrappers/unresolved 항목에 채우고
+// 도달 가능한 호출 지점(주소 참조, 데이터 재배치 포함)이 하나도 없는 래퍼를 DeadImport 로 표시
+// (ImportCallSites 는 코드 스캔으로 확인할 수 없는 재배치를 DataRef 로 넣으므로 호출 지점이 없으면 모든 재배치를 확인한 것)
+func (r *Report) AddCallSites(sites map[string][]analyzer.ImportCall) {
+	for i := range r.Wrappers {
+		w := &r.Wrappers[i]
+		w.CallSites, w.DeadImport = convertCallSites(sites[w.Name], false), !anyReachable(sites[w.Name])
+	}
+	for i := range r.Unresolved {
+		u := &r.Unresolved[i]
+		u.CallSites, u.DeadImport = convertCallSites(sites[u.Name], false), !anyReachable(sites[u.Name])
+	}
+}
+
+// SeparateUnreachable : DeadImport 래퍼를 wrappers 에서 unreachable 로 옮겨 허용 목록에서 뺌 (--drop-dead-imports, 기본값)
+// 허용 목록에 있던 래퍼는 reason 을 dead-import 로 기록하며, 반환값은 새로 허용 목록에서 뺀 래퍼 이름
+func (r *Report) SeparateUnreachable() []string {
+	var dropped []string
+	reachable := r.Wrappers[:0]
+	for _, w := range r.Wrappers {
+		if !w.DeadImport {
+			reachable = append(reachable, w)
+			continue
+		}
+		if w.Allowed {
+			w.Allowed, w.Reason = false, string(processor.ReasonDeadImport)
+			r.Coverage.Allowed--
+			r.Coverage.Reasons[w.Reason]++
+			dropped = append(dropped, w.Name)
+		}
+		r.Unreachable = append(r.Unreachable, w)
+	}
+	r.Wrappers = reachable
+	r.Syscalls = export.AllowList(r.SyscallMap())
+	return dropped
+}
+
+func anyReachable(sites []analyzer.ImportCall) bool {
+	for _, site := range sites {
+		if site.Reachable {
+			return true
+		}
+	}
+	return false
+}
+
+// AddArguments : 래퍼별 호출 지점(analyzer.ImportCallSites 결과)을 인자 명세(syscalls.GetArgSpecs)에 따라 정리
+func (r *Report) AddArguments(sites map[string][]analyzer.ImportCall) {
+	names := make([]string, 0, len(sites))
+	for name := range sites {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	syscallOf := r.SyscallMap()
+	for _, name := range names {
+		specs, ok := syscalls.GetArgSpecs(name)
+		if !ok || len(sites[name]) == 0 {
+			continue
+		}
+		arg := Argument{Wrapper: name, Syscall: syscallOf[name], CallSites: convertCallSites(sites[name], true)}
+		for _, spec := range specs {
+			arg.Params = append(arg.Params, summarizeParam(spec, sites[name]))
+		}
+		r.Arguments = append(r.Arguments, arg)
+	}
+}
+
+// summarizeParam : 인자 하나의 호출 지점별 값을 중복 없이 모음
+func summarizeParam(spec syscalls.ArgSpec, sites []analyzer.ImportCall) Param {
+	p := Param{
+		Name:       spec.Name,
+		Register:   asmanalysis.ArgRegNames[spec.Reg],
+		SyscallArg: spec.SyscallArg,
+		Values:     []ParamValue{},
+	}
+	seen := make(map[int64]struct{})
+	var values []int64
+	for _, site := range sites {
+		if site.AddressTaken {
+			continue // 호출이 아니므로 인자 없음
+		}
+		v := site.Args[spec.Reg]
+		if !v.Known {
+			p.Unknown++
+			continue
+		}
+		if _, ok := seen[v.Value]; !ok {
+			seen[v.Value] = struct{}{}
+			values = append(values, v.Value)
+		}
+	}
+	sort.Slice(values, func(i, j int) bool { return uint64(values[i]) < uint64(values[j]) })
+	for _, v := range values {
+		pv := ParamValue{Value: fmt.Sprintf("0x%x", uint64(v))}
+		if spec.Decode != nil {
+			pv.Name = spec.Decode(v)
+		}
+		p.Values = append(p.Values, pv)
+	}
+	return p
+}
+
+// convertCallSites : 호출 지점을 보고서 형식으로 (withArgs면 상수로 확인된 인자 레지스터 값 포함)
+func convertCallSites(sites []analyzer.ImportCall, withArgs bool) []CallSite {
+	var out []CallSite
+	for _, site := range sites {
+		cs := CallSite{
+			Address:      hexAddress(site.Address),
+			Function:     site.Caller,
+			Tail:         site.Tail,
+			Indirect:     site.Indirect,
+			AddressTaken: site.AddressTaken,
+			DataRef:      site.DataRef,
+			Unreachable:  !site.Reachable,
+		}
+		if withArgs && !site.AddressTaken {
+			cs.Args = map[string]string{}
+			for i, v := range site.Args {
+				if v.Known {
+					cs.Args[asmanalysis.ArgRegNames[i]] = fmt.Sprintf("0x%x", uint64(v.Value))
+				}
+			}
+		}
+		out = append(out, cs)
+	}
+	return out
+}
+
+func convertCandidates(t *processor.Trace) []Candidate {
+	var candidates []Candidate
+	for _, c := range t.Candidates {
+		name, _ := syscalls.GetKernelSyscallName(c.Number)
+		candidates = append(candidates, Candidate{
+			Address:    hexAddress(c.Address),
+			Number:     c.Number,
+			Syscall:    name,
+			Confidence: c.Confidence.String(),
+		})
+	}
+	return candidates
+}
+
+func hexAddress(addr uint64) string {
+	if addr == 0 {
+		return ""
+	}
+	return fmt.Sprintf("0x%x", addr)
+}
+
+func syscallNumber(name string) int64 {
+	if nr, ok := syscalls.GetKernelSyscallNumber(name); ok {
+		return nr
+	}
+	return -1
+}
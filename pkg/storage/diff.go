@@ -0,0 +1,85 @@
+package storage
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+)
+
+// 변경 이벤트 종류
+const (
+	ChangeCreated = "created" // 처음 저장된 바이너리
+	ChangeUpdated = "updated" // 기존 결과와 시스템 콜 집합이 달라짐
+	ChangeDeleted = "deleted" // 결과가 삭제됨
+)
+
+// SyscallDiff는 바이너리 하나의 저장 전/후 시스템 콜 집합 차이
+// Redis 저장소는 이 값을 JSON으로 pub/sub 채널과 Stream에 발행함
+type SyscallDiff struct {
+	Change    string    `json:"change"` // ChangeCreated / ChangeUpdated / ChangeDeleted
+	SHA256    string    `json:"sha256"`
+	Path      string    `json:"path,omitempty"`
+	Previous  string    `json:"previous_sha256,omitempty"` // 같은 경로에 이전에 저장된 바이너리 sha256 (다른 버전과 비교했을 때)
+	Added     []string  `json:"added"`
+	Removed   []string  `json:"removed"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Changed : 시스템 콜 집합이 실제로 달라졌는지 (처음 저장/삭제는 항상 변경으로 봄)
+func (d *SyscallDiff) Changed() bool {
+	return d.Change != ChangeUpdated || len(d.Added) > 0 || len(d.Removed) > 0
+}
+
+// JSON : 발행용 JSON 문자열
+func (d *SyscallDiff) JSON() string {
+	data, _ := json.Marshal(d)
+	return string(data)
+}
+
+// DiffSyscalls : 이전/새 시스템 콜 목록의 차이 (둘 다 정렬된 결과 반환)
+func DiffSyscalls(prev, next []string) (added, removed []string) {
+	prevSet := make(map[string]struct{}, len(prev))
+	for _, name := range prev {
+		prevSet[name] = struct{}{}
+	}
+	nextSet := make(map[string]struct{}, len(next))
+	for _, name := range next {
+		nextSet[name] = struct{}{}
+		if _, ok := prevSet[name]; !ok {
+			added = append(added, name)
+		}
+	}
+	for _, name := range prev {
+		if _, ok := nextSet[name]; !ok {
+			removed = append(removed, name)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
+
+// newSyscallDiff : 이전 결과 존재 여부와 두 목록으로 SyscallDiff 생성
+// prevID 는 비교한 이전 결과의 sha256 (같은 경로의 이전 버전), a.SHA256 과 같으면 기록하지 않음
+func newSyscallDiff(a *Analysis, existed bool, prevID string, prev []string) *SyscallDiff {
+	d := &SyscallDiff{
+		Change:    ChangeUpdated,
+		SHA256:    a.SHA256,
+		Path:      a.Path,
+		Timestamp: time.Now().UTC(),
+	}
+	if prevID != a.SHA256 {
+		d.Previous = prevID
+	}
+	if !existed {
+		d.Change = ChangeCreated
+	}
+	d.Added, d.Removed = DiffSyscalls(prev, a.Syscalls)
+	if d.Added == nil {
+		d.Added = []string{}
+	}
+	if d.Removed == nil {
+		d.Removed = []string{}
+	}
+	return d
+}
@@ -0,0 +1,180 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// FileStore는 디렉터리 하나에 바이너리별 JSON 파일(<sha256>.json)로 결과를 저장하는 ResultStore 구현
+// Redis 서버가 없는 CI나 로컬 환경에서 사용
+type FileStore struct {
+	dir string
+}
+
+// NewFileStore : dir 아래에 결과를 저장하는 FileStore 생성 (디렉터리가 없으면 생성)
+func NewFileStore(dir string) (*FileStore, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("결과 저장 디렉터리가 지정되지 않았습니다")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("결과 저장 디렉터리 생성 실패 (%s): %w", dir, err)
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+// 저장 키를 파일 이름으로 변환 (경로 구분자 등은 이스케이프)
+func (s *FileStore) path(id string) string {
+	return filepath.Join(s.dir, url.PathEscape(id)+".json")
+}
+
+// Save : 임시 파일에 쓴 뒤 rename 하여 반쯤 쓰인 결과가 남지 않도록 저장
+// 같은 경로에 저장된 이전 버전(다른 sha256)의 결과는 지움
+func (s *FileStore) Save(ctx context.Context, a *Analysis) (*SyscallDiff, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	data, err := json.MarshalIndent(a, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("분석 결과 직렬화 실패: %w", err)
+	}
+
+	// 같은 경로의 이전 결과(다른 sha256 이면 이전 버전)와 비교 (파일 저장소는 발행할 곳이 없으므로 차이만 반환)
+	old, err := s.previous(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+	var prev []string
+	prevID := ""
+	if old != nil {
+		prev, prevID = old.Syscalls, old.SHA256
+	}
+	diff := newSyscallDiff(a, old != nil, prevID, prev)
+
+	if err := writeFileAtomic(s.dir, s.path(a.SHA256), data); err != nil {
+		return nil, err
+	}
+	// 같은 경로의 이전 버전은 새 결과로 대체
+	if diff.Previous != "" {
+		if err := os.Remove(s.path(diff.Previous)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("이전 버전 결과 삭제 실패: %w", err)
+		}
+	}
+	return diff, nil
+}
+
+// previous : a 와 비교할 이전 결과
+// 같은 경로로 저장된 결과 중 가장 최근 것 (바이너리가 새 버전으로 바뀌었으면 다른 sha256), 없으면 같은 sha256 의 결과
+func (s *FileStore) previous(ctx context.Context, a *Analysis) (*Analysis, error) {
+	var latest *Analysis
+	if a.Path != "" {
+		ids, err := s.List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range ids {
+			other, err := s.Load(ctx, id)
+			if errors.Is(err, ErrNotFound) {
+				continue // 목록을 읽은 뒤 삭제됨
+			}
+			if err != nil {
+				return nil, err
+			}
+			if other.Path == a.Path && (latest == nil || other.AnalyzedAt.After(latest.AnalyzedAt)) {
+				latest = other
+			}
+		}
+	}
+	if latest != nil {
+		return latest, nil
+	}
+	old, err := s.Load(ctx, a.SHA256)
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
+	return old, err
+}
+
+// writeFileAtomic : dir 안의 임시 파일에 쓴 뒤 path로 rename (같은 파일 시스템이어야 함)
+func writeFileAtomic(dir, path string, data []byte) error {
+	tmp, err := os.CreateTemp(dir, ".tmp-*.json")
+	if err != nil {
+		return fmt.Errorf("임시 파일 생성 실패: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // rename 성공 후에는 아무 일도 하지 않음
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("파일 쓰기 실패: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("파일 쓰기 실패: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("파일 교체 실패 (%s): %w", path, err)
+	}
+	return nil
+}
+
+// Load : 바이너리의 JSON 파일을 읽음
+func (s *FileStore) Load(_ context.Context, sha256 string) (*Analysis, error) {
+	data, err := os.ReadFile(s.path(sha256))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("분석 결과 읽기 실패: %w", err)
+	}
+
+	var a Analysis
+	if err := json.Unmarshal(data, &a); err != nil {
+		return nil, fmt.Errorf("분석 결과 파싱 실패 (%s): %w", s.path(sha256), err)
+	}
+	return &a, nil
+}
+
+// List : 디렉터리의 결과 파일 이름을 저장 키(sha256)로 되돌려 반환
+func (s *FileStore) List(_ context.Context) ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("결과 디렉터리 읽기 실패: %w", err)
+	}
+
+	var ids []string
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || strings.HasPrefix(name, ".") || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		id, err := url.PathUnescape(strings.TrimSuffix(name, ".json"))
+		if err != nil {
+			continue // 이 저장소가 만든 파일이 아님
+		}
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// Delete : 바이너리의 JSON 파일 삭제
+func (s *FileStore) Delete(ctx context.Context, sha256 string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	err := os.Remove(s.path(sha256))
+	if errors.Is(err, os.ErrNotExist) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// Close : FileStore는 정리할 자원이 없음
+func (s *FileStore) Close() error {
+	return nil
+}
@@ -0,0 +1,218 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newTestAnalysis : 테스트용 분석 결과 ({wrapper: kernelSyscall} 는 래퍼 이름과 같은 시스템 콜)
+func newTestAnalysis(sha, path string, at time.Time, syscalls ...string) *Analysis {
+	wrappers := make(map[string]string, len(syscalls))
+	for _, name := range syscalls {
+		wrappers[name] = name
+	}
+	return NewAnalysis(Meta{SHA256: sha, Path: path, AnalyzerVersion: "test", AnalyzedAt: at}, wrappers)
+}
+
+func newTestFileStore(t *testing.T) *FileStore {
+	t.Helper()
+	s, err := NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileStore: %v", err)
+	}
+	return s
+}
+
+// TestFileStoreSaveLoadDiff : 저장 -> 조회 왕복과, 같은 경로에 다시 저장할 때의 차이
+func TestFileStoreSaveLoadDiff(t *testing.T) {
+	ctx := context.Background()
+	t0 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		save        *Analysis
+		wantChange  string
+		wantPrev    string
+		wantAdded   []string
+		wantRemoved []string
+		wantIDs     []string
+	}{
+		{
+			name:       "first save",
+			save:       newTestAnalysis("aaa", "/bin/app", t0, "read", "write"),
+			wantChange: ChangeCreated,
+			wantAdded:  []string{"read", "write"},
+			wantIDs:    []string{"aaa"},
+		},
+		{
+			name:       "same sha again",
+			save:       newTestAnalysis("aaa", "/bin/app", t0.Add(time.Minute), "read", "write"),
+			wantChange: ChangeUpdated,
+			wantIDs:    []string{"aaa"},
+		},
+		{
+			name:        "new build at the same path",
+			save:        newTestAnalysis("bbb", "/bin/app", t0.Add(2*time.Minute), "read", "ptrace"),
+			wantChange:  ChangeUpdated,
+			wantPrev:    "aaa",
+			wantAdded:   []string{"ptrace"},
+			wantRemoved: []string{"write"},
+			wantIDs:     []string{"bbb"}, // 이전 버전은 대체됨
+		},
+		{
+			name:       "other path",
+			save:       newTestAnalysis("ccc", "/bin/other", t0.Add(3*time.Minute), "read"),
+			wantChange: ChangeCreated,
+			wantAdded:  []string{"read"},
+			wantIDs:    []string{"bbb", "ccc"},
+		},
+	}
+
+	s := newTestFileStore(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff, err := s.Save(ctx, tt.save)
+			if err != nil {
+				t.Fatalf("Save: %v", err)
+			}
+			if diff.Change != tt.wantChange || diff.Previous != tt.wantPrev {
+				t.Errorf("change = %s (previous %q), want %s (previous %q)", diff.Change, diff.Previous, tt.wantChange, tt.wantPrev)
+			}
+			if !equalStrings(diff.Added, tt.wantAdded) || !equalStrings(diff.Removed, tt.wantRemoved) {
+				t.Errorf("added %v removed %v, want added %v removed %v", diff.Added, diff.Removed, tt.wantAdded, tt.wantRemoved)
+			}
+			if wantChanged := tt.wantChange != ChangeUpdated || len(tt.wantAdded)+len(tt.wantRemoved) > 0; diff.Changed() != wantChanged {
+				t.Errorf("Changed() = %t, want %t", diff.Changed(), wantChanged)
+			}
+
+			got, err := s.Load(ctx, tt.save.SHA256)
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.save) {
+				t.Errorf("Load = %+v, want %+v", got, tt.save)
+			}
+
+			ids, err := s.List(ctx)
+			if err != nil {
+				t.Fatalf("List: %v", err)
+			}
+			if !equalStrings(ids, tt.wantIDs) {
+				t.Errorf("List = %v, want %v", ids, tt.wantIDs)
+			}
+		})
+	}
+}
+
+// TestFileStoreDelete : 삭제 후 조회/재삭제는 ErrNotFound
+func TestFileStoreDelete(t *testing.T) {
+	ctx := context.Background()
+	s := newTestFileStore(t)
+	if _, err := s.Save(ctx, newTestAnalysis("aaa", "/bin/app", time.Now().UTC(), "read")); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if err := s.Delete(ctx, "aaa"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := s.Load(ctx, "aaa"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Load after Delete: err = %v, want ErrNotFound", err)
+	}
+	if err := s.Delete(ctx, "aaa"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("second Delete: err = %v, want ErrNotFound", err)
+	}
+}
+
+// TestFileStoreListSkipsTemp : 쓰는 중인 임시 파일과 하위 디렉터리는 목록에 나오지 않음
+func TestFileStoreListSkipsTemp(t *testing.T) {
+	ctx := context.Background()
+	s := newTestFileStore(t)
+	if err := os.WriteFile(filepath.Join(s.dir, ".tmp-123.json"), []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(s.dir, "libc"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Save(ctx, newTestAnalysis("a/b", "/bin/app", time.Now().UTC(), "read")); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	ids, err := s.List(ctx)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if !equalStrings(ids, []string{"a/b"}) {
+		t.Errorf("List = %v, want [a/b]", ids)
+	}
+}
+
+// TestFileStoreConcurrentSave : 같은 키를 동시에 저장해도 rename 교체로 항상 완전한 결과 하나만 남음
+func TestFileStoreConcurrentSave(t *testing.T) {
+	ctx := context.Background()
+	s := newTestFileStore(t)
+
+	const writers = 16
+	var wg sync.WaitGroup
+	errs := make(chan error, writers)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			a := newTestAnalysis("aaa", "/bin/app", time.Now().UTC(), "read", fmt.Sprintf("sys%02d", i))
+			if _, err := s.Save(ctx, a); err != nil {
+				errs <- err
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("Save: %v", err)
+	}
+
+	got, err := s.Load(ctx, "aaa")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(got.Syscalls) != 2 || got.Syscalls[0] != "read" {
+		t.Errorf("Syscalls = %v, want read + one writer's syscall", got.Syscalls)
+	}
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("directory has %d entries, want 1 (temp files left behind?)", len(entries))
+	}
+}
+
+// TestFileStoreCanceled : 취소된 컨텍스트로는 저장/삭제하지 않음
+func TestFileStoreCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	s := newTestFileStore(t)
+	if _, err := s.Save(ctx, newTestAnalysis("aaa", "/bin/app", time.Now().UTC(), "read")); !errors.Is(err, context.Canceled) {
+		t.Errorf("Save: err = %v, want context.Canceled", err)
+	}
+	if err := s.Delete(ctx, "aaa"); !errors.Is(err, context.Canceled) {
+		t.Errorf("Delete: err = %v, want context.Canceled", err)
+	}
+}
+
+// equalStrings : nil 과 빈 슬라이스를 같게 보는 비교
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,196 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// LibcTable은 libc 하나에 대해 export 심볼별로 추적한 커널 시스템 콜 표
+// 같은 libc(build-id)에 링크된 모든 바이너리가 재사용하므로 libc 추적은 심볼당 한 번만 수행됨
+// 값이 빈 문자열이면 "추적했지만 시스템 콜을 찾지 못함"을 뜻함 (다시 추적하지 않도록 기록)
+// Tracepoint 필터링은 실행 환경(커널)에 따라 다르므로 적용하지 않은 값을 저장
+type LibcTable struct {
+	Key             string            `json:"key"`                // 저장 키 (LibcTableKey)
+	Path            string            `json:"path"`               // 추적한 libc 경로
+	BuildID         string            `json:"build_id,omitempty"` // libc의 NT_GNU_BUILD_ID
+	SHA256          string            `json:"sha256"`
+	AnalyzerVersion string            `json:"analyzer_version"`
+	UpdatedAt       time.Time         `json:"updated_at"`
+	Syscalls        map[string]string `json:"syscalls"`             // {export 심볼: kernelSyscall}
+	Reasons         map[string]string `json:"reasons,omitempty"`    // {export 심볼: reason 코드}, Syscalls 값이 ""인 심볼만 (processor.Reason)
+	Confidence      map[string]string `json:"confidence,omitempty"` // {export 심볼: 신뢰도}, Syscalls 값이 있는 심볼만 (asmanalysis.Confidence)
+	Wrappers        []string          `json:"wrappers,omitempty"`   // syscall 명령어가 있는 export 함수 (--catalog=libc 목록, 계산 전이면 nil)
+}
+
+// LibcTableKey : libc 표의 저장 키 (build-id가 있으면 build-id, 없으면 "sha256-<sha256>")
+func LibcTableKey(buildID, sha256 string) string {
+	if buildID != "" {
+		return buildID
+	}
+	return "sha256-" + sha256
+}
+
+// NewLibcTable : 비어 있는 libc 표 생성
+func NewLibcTable(path, buildID, sha256, analyzerVersion string) *LibcTable {
+	return &LibcTable{
+		Key:             LibcTableKey(buildID, sha256),
+		Path:            path,
+		BuildID:         buildID,
+		SHA256:          sha256,
+		AnalyzerVersion: analyzerVersion,
+		Syscalls:        make(map[string]string),
+		Reasons:         make(map[string]string),
+		Confidence:      make(map[string]string),
+	}
+}
+
+// --- FileStore: <dir>/libc/<key>.json ---
+
+func (s *FileStore) libcPath(key string) string {
+	return filepath.Join(s.dir, "libc", key+".json")
+}
+
+// LoadLibcTable : libc 표 JSON 파일을 읽음
+func (s *FileStore) LoadLibcTable(_ context.Context, key string) (*LibcTable, error) {
+	data, err := os.ReadFile(s.libcPath(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("libc 표 읽기 실패: %w", err)
+	}
+
+	var t LibcTable
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, fmt.Errorf("libc 표 파싱 실패 (%s): %w", s.libcPath(key), err)
+	}
+	if t.Syscalls == nil {
+		t.Syscalls = make(map[string]string)
+	}
+	if t.Reasons == nil {
+		t.Reasons = make(map[string]string)
+	}
+	if t.Confidence == nil {
+		t.Confidence = make(map[string]string)
+	}
+	return &t, nil
+}
+
+// SaveLibcTable : libc 표를 통째로 다시 씀 (Save와 같이 임시 파일 + rename)
+func (s *FileStore) SaveLibcTable(ctx context.Context, t *LibcTable) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(t, "", "  ")
+	if err != nil {
+		return fmt.Errorf("libc 표 직렬화 실패: %w", err)
+	}
+	dir := filepath.Dir(s.libcPath(t.Key))
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("libc 표 디렉터리 생성 실패 (%s): %w", dir, err)
+	}
+	return writeFileAtomic(dir, s.libcPath(t.Key), data)
+}
+
+// --- RedisStore: ips:libc:<key>:syscalls (HASH), ips:libc:<key>:reasons (HASH), ips:libc:<key>:confidence (HASH), ips:libc:<key>:wrappers (SET), ips:libc:<key>:meta (HASH) ---
+
+// LibcKey : libc 표에 속한 Redis 키 ("ips:libc:<key>:<suffix>")
+func LibcKey(key, suffix string) string {
+	return libcKeyPrefix + key + ":" + suffix
+}
+
+// LoadLibcTable : libc 표의 meta/syscalls/reasons/confidence 해시와 wrappers 집합을 읽음
+func (s *RedisStore) LoadLibcTable(ctx context.Context, key string) (*LibcTable, error) {
+	pipe := s.rdb.Pipeline()
+	metaCmd := pipe.HGetAll(ctx, LibcKey(key, "meta"))
+	syscallsCmd := pipe.HGetAll(ctx, LibcKey(key, "syscalls"))
+	reasonsCmd := pipe.HGetAll(ctx, LibcKey(key, "reasons"))
+	confidenceCmd := pipe.HGetAll(ctx, LibcKey(key, "confidence"))
+	wrappersCmd := pipe.SMembers(ctx, LibcKey(key, "wrappers"))
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, fmt.Errorf("Redis 조회 실패: %w", err)
+	}
+
+	meta := metaCmd.Val()
+	if len(meta) == 0 {
+		return nil, ErrNotFound
+	}
+	updatedAt, _ := time.Parse(time.RFC3339, meta["updated_at"])
+	var wrappers []string
+	if members := wrappersCmd.Val(); len(members) > 0 {
+		wrappers = members
+		sort.Strings(wrappers)
+	}
+	return &LibcTable{
+		Key:             key,
+		Path:            meta["path"],
+		BuildID:         meta["build_id"],
+		SHA256:          meta["sha256"],
+		AnalyzerVersion: meta["analyzer_version"],
+		UpdatedAt:       updatedAt,
+		Syscalls:        syscallsCmd.Val(),
+		Reasons:         reasonsCmd.Val(),
+		Confidence:      confidenceCmd.Val(),
+		Wrappers:        wrappers,
+	}, nil
+}
+
+// SaveLibcTable : libc 표를 하나의 MULTI 트랜잭션으로 교체
+// 같은 build-id의 표는 어느 Job이 만들어도 같은 값이므로 동시에 저장해도 마지막 쓰기가 이기면 됨
+func (s *RedisStore) SaveLibcTable(ctx context.Context, t *LibcTable) error {
+	entries := make(map[string]interface{}, len(t.Syscalls))
+	for symbol, kernelName := range t.Syscalls {
+		entries[symbol] = kernelName
+	}
+	reasons := make(map[string]interface{}, len(t.Reasons))
+	for symbol, reason := range t.Reasons {
+		reasons[symbol] = reason
+	}
+	confidence := make(map[string]interface{}, len(t.Confidence))
+	for symbol, level := range t.Confidence {
+		confidence[symbol] = level
+	}
+	wrappers := make([]interface{}, len(t.Wrappers))
+	for i, name := range t.Wrappers {
+		wrappers[i] = name
+	}
+
+	return s.retry.Do(ctx, "Redis libc 표 저장", func() error {
+		_, err := s.rdb.TxPipelined(ctx, func(p redis.Pipeliner) error {
+			p.Del(ctx, LibcKey(t.Key, "syscalls"))
+			if len(entries) > 0 {
+				p.HSet(ctx, LibcKey(t.Key, "syscalls"), entries)
+			}
+			p.Del(ctx, LibcKey(t.Key, "reasons"))
+			if len(reasons) > 0 {
+				p.HSet(ctx, LibcKey(t.Key, "reasons"), reasons)
+			}
+			p.Del(ctx, LibcKey(t.Key, "confidence"))
+			if len(confidence) > 0 {
+				p.HSet(ctx, LibcKey(t.Key, "confidence"), confidence)
+			}
+			p.Del(ctx, LibcKey(t.Key, "wrappers"))
+			if len(wrappers) > 0 {
+				p.SAdd(ctx, LibcKey(t.Key, "wrappers"), wrappers...)
+			}
+			p.HSet(ctx, LibcKey(t.Key, "meta"), map[string]interface{}{
+				"schema":           SchemaVersion,
+				"path":             t.Path,
+				"build_id":         t.BuildID,
+				"sha256":           t.SHA256,
+				"analyzer_version": t.AnalyzerVersion,
+				"updated_at":       t.UpdatedAt.UTC().Format(time.RFC3339),
+			})
+			return nil
+		})
+		return err
+	})
+}
@@ -0,0 +1,14 @@
+package storage
+
+import "context"
+
+// nopStore는 --store=none 일 때 사용하는 아무것도 저장하지 않는 ResultStore
+type nopStore struct{}
+
+func (nopStore) Save(context.Context, *Analysis) (*SyscallDiff, error)     { return nil, nil }
+func (nopStore) Load(context.Context, string) (*Analysis, error)           { return nil, ErrNotFound }
+func (nopStore) List(context.Context) ([]string, error)                    { return nil, nil }
+func (nopStore) Delete(context.Context, string) error                      { return ErrNotFound }
+func (nopStore) LoadLibcTable(context.Context, string) (*LibcTable, error) { return nil, ErrNotFound }
+func (nopStore) SaveLibcTable(context.Context, *LibcTable) error           { return nil }
+func (nopStore) Close() error                                              { return nil }
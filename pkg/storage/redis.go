@@ -2,22 +2,529 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/redis/go-redis/v9" // Redis 클라이언트 임포트
 )
 
-func NewRedisClient(addr, password string) (*redis.Client, error) {
+// Redis 키 스키마
+//
+//	ips:binaries                        SET    분석 결과가 저장된 바이너리 sha256 목록 (인덱스)
+//	ips:binary:<sha256>:syscalls        SET    바이너리가 호출할 수 있는 커널 시스템 콜 이름
+//	ips:binary:<sha256>:wrappers        HASH   libc 래퍼 -> 커널 시스템 콜 이름
+//	ips:binary:<sha256>:confidence      HASH   libc 래퍼 -> 신뢰도 (exact, propagated, heuristic-alias, transitive)
+//	ips:binary:<sha256>:meta            HASH   path, build_id, libc_path, libc_build_id, libc_sha256, analyzer_version, min_confidence, drop_dead_imports, dropped_imports, analyzed_at, schema
+//	ips:binary:<sha256>:bitmap:<arch>   STRING 허용 비트맵 64바이트 원본 (bpf_map_update_elem 값)
+//	ips:path:<path>                     STRING 경로에 마지막으로 저장된 바이너리 sha256 (새 버전 저장 시 이전 버전과의 차이 계산)
+//	ips:libc:<build-id>:syscalls        HASH   libc export 심볼 -> 커널 시스템 콜 이름 ("" = 찾지 못함), build-id가 없으면 키는 sha256-<sha256>
+//	ips:libc:<build-id>:reasons         HASH   시스템 콜을 찾지 못한 libc 심볼 -> reason 코드 (processor.Reason)
+//	ips:libc:<build-id>:confidence      HASH   시스템 콜을 찾은 libc 심볼 -> 신뢰도
+//	ips:libc:<build-id>:wrappers        SET    syscall 명령어가 있는 libc export 함수 (--catalog=libc 래퍼 목록)
+//	ips:libc:<build-id>:meta            HASH   path, build_id, sha256, analyzer_version, updated_at, schema
+//	cluster_callable_syscalls           SET    모든 ips:binary:*:syscalls 의 합집합 (SyscallService가 읽는 키)
+//	ips:staging:<sha256>:<token>:*      (임시) 저장 중인 값, 교체 후 사라지며 실패 시 stagingTTL 후 만료
+//	ips:events:syscalls                 PUBSUB 바이너리의 시스템 콜 집합이 바뀔 때마다 SyscallDiff JSON 발행
+//	ips:stream:syscall-changes          STREAM 위와 같은 이벤트의 영속 기록 (필드: change, sha256, path, added, removed, payload)
+//
+// cluster_callable_syscalls 는 직접 쓰지 않고 항상 인덱스에서 다시 계산함 (recomputeClusterScript)
+// 같은 경로에 새 sha256 을 저장하면 이전 sha256 의 키와 인덱스 항목은 지워짐 (이전 버전의 시스템 콜이 합집합에 남지 않도록)
+const (
+	// SchemaVersion : 위 키 스키마 버전 (meta 해시의 schema 필드)
+	SchemaVersion = "1"
+
+	// ClusterSyscallSetKey : 클러스터에서 호출 가능한 커널 시스템 콜 Set (SyscallService가 읽는 키)
+	ClusterSyscallSetKey = "cluster_callable_syscalls"
+	// BinaryIndexKey : 분석 결과가 저장된 바이너리 sha256 Set
+	BinaryIndexKey = "ips:binaries"
+	// ChangeChannel : 시스템 콜 집합 변경 알림 pub/sub 채널 (IPS 에이전트가 구독하여 BPF 맵 재적재)
+	ChangeChannel = "ips:events:syscalls"
+	// ChangeStreamKey : 변경 알림 Stream (구독 중이 아니던 소비자도 놓치지 않도록)
+	ChangeStreamKey = "ips:stream:syscall-changes"
+
+	// changeStreamMaxLen : Stream에 유지할 대략적인 최대 이벤트 수
+	changeStreamMaxLen = 10000
+
+	keyPrefix     = "ips:binary:"
+	pathKeyPrefix = "ips:path:"
+	libcKeyPrefix = "ips:libc:"
+	stagingPrefix = "ips:staging:"
+
+	// stagingTTL : 교체되지 못한 임시 키의 만료 시간
+	stagingTTL = 10 * time.Minute
+)
+
+// binaryKeySuffixes : 바이너리 하나를 구성하는 키 (교체/삭제 대상)
+// 비트맵은 export.BitmapArch(x86_64) 하나만 저장 (분석기가 다른 아키텍처 대상을 거부)
+var binaryKeySuffixes = []string{"syscalls", "wrappers", "confidence", "meta", "bitmap:x86_64"}
+
+// BinaryKey : 바이너리 하나에 속한 키 ("ips:binary:<sha256>:<suffix>")
+func BinaryKey(sha256, suffix string) string {
+	return keyPrefix + sha256 + ":" + suffix
+}
+
+// binaryKeys : 바이너리 하나에 속한 모든 키 (binaryKeySuffixes)
+func binaryKeys(sha256 string) []string {
+	keys := make([]string, len(binaryKeySuffixes))
+	for i, suffix := range binaryKeySuffixes {
+		keys[i] = BinaryKey(sha256, suffix)
+	}
+	return keys
+}
+
+// PathKey : 경로에 마지막으로 저장된 바이너리 sha256 을 가리키는 키 ("ips:path:<path>")
+func PathKey(path string) string {
+	return pathKeyPrefix + path
+}
+
+// SyscallBitmapKey : 바이너리별 시스템 콜 허용 비트맵을 저장하는 Redis 키
+// 값은 export.SyscallBitmap 의 64바이트 원본 그대로이므로 로더가 GET 결과를 바로 bpf_map_update_elem 에 넘길 수 있음
+func SyscallBitmapKey(sha256, arch string) string {
+	return BinaryKey(sha256, "bitmap:"+arch)
+}
+
+// recomputeClusterScript : 바이너리 syscalls Set 합집합으로 클러스터 Set을 원자적으로 다시 만듦
+// KEYS[1] 은 클러스터 Set, KEYS[2..] 는 인덱스의 바이너리별 syscalls Set (clusterKeys, 접근하는 키를 모두 KEYS 로 선언)
+// Lua unpack 의 인자 수 제한을 넘지 않도록 ARGV[1] 개씩 나누어 SUNIONSTORE
+var recomputeClusterScript = redis.NewScript(`
+redis.call('DEL', KEYS[1])
+local chunk = tonumber(ARGV[1])
+for i = 2, #KEYS, chunk do
+	redis.call('SUNIONSTORE', KEYS[1], KEYS[1], unpack(KEYS, i, math.min(i + chunk - 1, #KEYS)))
+end
+return redis.call('SCARD', KEYS[1])
+`)
+
+// recomputeChunk : recomputeClusterScript 가 SUNIONSTORE 한 번에 합치는 Set 수
+const recomputeChunk = 1000
+
+// clusterKeys : recomputeClusterScript 의 KEYS (클러스터 Set, 바이너리별 syscalls Set)
+func clusterKeys(ids []string) []string {
+	keys := make([]string, 0, len(ids)+1)
+	keys = append(keys, ClusterSyscallSetKey)
+	for _, id := range ids {
+		keys = append(keys, BinaryKey(id, "syscalls"))
+	}
+	return keys
+}
+
+// execErr : MULTI 트랜잭션 안의 명령별 오류 중 첫 번째 (redis.Nil 제외)
+// EXEC 는 명령 하나가 실패해도 나머지를 실행하므로, 교체가 일부만 적용된 것을 놓치지 않도록 모두 확인
+func execErr(cmds []redis.Cmder) error {
+	for _, cmd := range cmds {
+		if err := cmd.Err(); err != nil && !errors.Is(err, redis.Nil) {
+			return fmt.Errorf("%s: %w", cmd.Name(), err)
+		}
+	}
+	return nil
+}
+
+// NewRedisClient : Redis 클라이언트를 만들고 PING으로 연결을 확인
+// 연결되지 않으면 retry 정책에 따라 재시도하고, 끝내 실패하면 오류를 반환 (프로세스를 종료하지 않음)
+func NewRedisClient(ctx context.Context, addr, password string, retry RetryPolicy) (*redis.Client, error) {
 	rdb := redis.NewClient(&redis.Options{
-		Addr:     addr,
-		Password: password,
+		Addr:       addr,
+		Password:   password,
+		MaxRetries: -1, // 재시도는 RetryPolicy가 담당 (중복 재시도로 대기 시간이 늘어나지 않도록)
+	})
+	err := retry.Do(ctx, "Redis 연결 ("+addr+")", func() error {
+		return rdb.Ping(ctx).Err()
 	})
-	ctx := context.Background()
-	if err := rdb.Ping(ctx).Err(); err != nil {
-		log.Fatalf("Redis 연결 실패 (%s): %v\n", addr, err)
+	if err != nil {
+		rdb.Close()
+		return nil, err
 	}
-	fmt.Printf("Redis 연결 성공: %s\n", addr)
+	log.Printf("Redis 연결 성공: %s\n", addr)
 
 	return rdb, nil
 }
+
+// RedisStore는 Redis를 사용하는 ResultStore 구현
+type RedisStore struct {
+	rdb   *redis.Client
+	retry RetryPolicy
+}
+
+// NewRedisStore : Redis에 연결하여 RedisStore 생성
+func NewRedisStore(ctx context.Context, addr, password string, retry RetryPolicy) (*RedisStore, error) {
+	rdb, err := NewRedisClient(ctx, addr, password, retry)
+	if err != nil {
+		return nil, err
+	}
+	return &RedisStore{rdb: rdb, retry: retry}, nil
+}
+
+// Save : 임시(staging) 키에 먼저 쓴 뒤 MULTI 트랜잭션 안에서 RENAME 으로 한 번에 교체하고 클러스터 Set을 다시 계산
+// 교체 도중 다른 클라이언트는 이전 결과 또는 새 결과만 보게 되며, 이전 결과에만 있던 시스템 콜은 합집합에서도 빠짐
+// 시스템 콜 집합이 바뀌었으면 같은 트랜잭션 안에서 ChangeChannel / ChangeStreamKey 로 변경 내용을 발행
+// 차이는 같은 경로에 마지막으로 저장된 결과(PathKey, 바이너리가 갱신되었으면 이전 sha256)와 비교하고,
+// 이전 sha256 의 결과가 아직 같은 경로의 것이면 인덱스와 키에서 지워 새 결과로 대체
+func (s *RedisStore) Save(ctx context.Context, a *Analysis) (*SyscallDiff, error) {
+	if a.SHA256 == "" {
+		return nil, fmt.Errorf("분석 결과에 sha256이 없습니다")
+	}
+	var diff *SyscallDiff
+	err := s.retry.Do(ctx, "Redis 저장", func() error {
+		var err error
+		diff, err = s.save(ctx, a)
+		return err
+	})
+	return diff, err
+}
+
+func (s *RedisStore) save(ctx context.Context, a *Analysis) (*SyscallDiff, error) {
+	id := a.SHA256
+	token := strconv.FormatInt(time.Now().UnixNano(), 36)
+	staging := func(suffix string) string {
+		return stagingPrefix + id + ":" + token + ":" + suffix
+	}
+
+	// 1. 임시 키에 새 결과 쓰기
+	pipe := s.rdb.Pipeline()
+	written := make(map[string]bool) // 값이 비어 있어 만들어지지 않는 키가 있으므로 기록
+
+	if len(a.Syscalls) > 0 {
+		members := make([]interface{}, len(a.Syscalls))
+		for i, name := range a.Syscalls {
+			members[i] = name
+		}
+		pipe.SAdd(ctx, staging("syscalls"), members...)
+		written["syscalls"] = true
+	}
+
+	wrappers := make(map[string]interface{}, len(a.Wrappers))
+	for wrapperName, kernelName := range a.Wrappers {
+		if kernelName != "" {
+			wrappers[wrapperName] = kernelName
+		}
+	}
+	if len(wrappers) > 0 {
+		pipe.HSet(ctx, staging("wrappers"), wrappers)
+		written["wrappers"] = true
+	}
+
+	confidence := make(map[string]interface{}, len(a.Confidence))
+	for wrapperName, level := range a.Confidence {
+		confidence[wrapperName] = level
+	}
+	if len(confidence) > 0 {
+		pipe.HSet(ctx, staging("confidence"), confidence)
+		written["confidence"] = true
+	}
+
+	pipe.HSet(ctx, staging("meta"), map[string]interface{}{
+		"schema":            SchemaVersion,
+		"sha256":            a.SHA256,
+		"path":              a.Path,
+		"build_id":          a.BuildID,
+		"libc_path":         a.LibcPath,
+		"libc_build_id":     a.LibcBuildID,
+		"libc_sha256":       a.LibcSHA256,
+		"analyzer_version":  a.AnalyzerVersion,
+		"min_confidence":    a.MinConfidence,
+		"drop_dead_imports": strconv.FormatBool(a.DropDeadImports),
+		"dropped_imports":   strings.Join(a.DroppedImports, ","),
+		"analyzed_at":       a.AnalyzedAt.UTC().Format(time.RFC3339),
+	})
+	written["meta"] = true
+	pipe.Set(ctx, staging("bitmap:x86_64"), a.Bitmap, 0)
+	written["bitmap:x86_64"] = true
+
+	for suffix := range written {
+		pipe.Expire(ctx, staging(suffix), stagingTTL)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("Redis 임시 키 쓰기 실패: %w", err)
+	}
+
+	// 2. 이전 결과(같은 경로의 마지막 sha256, 없으면 같은 sha256)와 인덱스를 WATCH 한 상태로 읽어 차이를 계산하고,
+	//    MULTI 안에서 교체 + 인덱스 등록(이전 버전 대체) + 경로 갱신 + 합집합 재계산 + 변경 알림 발행
+	//    (도중에 다른 Job이 같은 바이너리나 경로, 인덱스를 바꾸면 트랜잭션이 실패하고 재시도됨)
+	var diff *SyscallDiff
+	liveSyscalls, liveMeta := BinaryKey(id, "syscalls"), BinaryKey(id, "meta")
+	watched := []string{liveSyscalls, liveMeta, BinaryIndexKey}
+	if a.Path != "" {
+		watched = append(watched, PathKey(a.Path))
+	}
+	err := s.rdb.Watch(ctx, func(tx *redis.Tx) error {
+		// 임시 키가 만료되었으면 RENAME 이 실패하므로 처음부터 다시 씀 (재시도)
+		stagingKeys := make([]string, 0, len(written))
+		for suffix := range written {
+			stagingKeys = append(stagingKeys, staging(suffix))
+		}
+		if n, err := tx.Exists(ctx, stagingKeys...).Result(); err != nil {
+			return err
+		} else if int(n) != len(stagingKeys) {
+			return fmt.Errorf("임시 키 %d개 중 %d개만 남아 있습니다 (만료)", len(stagingKeys), n)
+		}
+
+		prevID := id
+		if a.Path != "" {
+			last, err := tx.Get(ctx, PathKey(a.Path)).Result()
+			if err != nil && !errors.Is(err, redis.Nil) {
+				return err
+			}
+			if last != "" && last != id {
+				prevID = last
+				if err := tx.Watch(ctx, BinaryKey(prevID, "syscalls"), BinaryKey(prevID, "meta")).Err(); err != nil {
+					return err
+				}
+			}
+		}
+		prev, err := tx.SMembers(ctx, BinaryKey(prevID, "syscalls")).Result()
+		if err != nil {
+			return err
+		}
+		existed, err := tx.Exists(ctx, BinaryKey(prevID, "meta")).Result()
+		if err != nil {
+			return err
+		}
+		diff = newSyscallDiff(a, existed > 0, prevID, prev)
+
+		// 이전 버전이 아직 이 경로의 결과이면 대체 (같은 파일이 다른 경로로 다시 저장된 경우는 남김)
+		replaced := ""
+		if prevID != id && existed > 0 {
+			prevPath, err := tx.HGet(ctx, BinaryKey(prevID, "meta"), "path").Result()
+			if err != nil && !errors.Is(err, redis.Nil) {
+				return err
+			}
+			if prevPath == a.Path {
+				replaced = prevID
+			}
+		}
+		ids, err := tx.SMembers(ctx, BinaryIndexKey).Result()
+		if err != nil {
+			return err
+		}
+		indexed := []string{id}
+		for _, other := range ids {
+			if other != id && other != replaced {
+				indexed = append(indexed, other)
+			}
+		}
+
+		cmds, err := tx.TxPipelined(ctx, func(p redis.Pipeliner) error {
+			for _, suffix := range binaryKeySuffixes {
+				if written[suffix] {
+					p.Rename(ctx, staging(suffix), BinaryKey(id, suffix))
+					p.Persist(ctx, BinaryKey(id, suffix))
+				} else {
+					p.Del(ctx, BinaryKey(id, suffix)) // 새 결과에 없는 키는 이전 값도 지움
+				}
+			}
+			p.SAdd(ctx, BinaryIndexKey, id)
+			if replaced != "" {
+				p.Del(ctx, binaryKeys(replaced)...)
+				p.SRem(ctx, BinaryIndexKey, replaced)
+			}
+			if a.Path != "" {
+				p.Set(ctx, PathKey(a.Path), id, 0)
+			}
+			recomputeClusterScript.Eval(ctx, p, clusterKeys(indexed), recomputeChunk)
+			if diff.Changed() {
+				publishChange(ctx, p, diff)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		return execErr(cmds)
+	}, watched...)
+	if err != nil {
+		return nil, fmt.Errorf("Redis 교체 트랜잭션 실행 실패: %w", err)
+	}
+	return diff, nil
+}
+
+// publishChange : 변경 내용을 pub/sub 채널과 Stream에 기록 (트랜잭션 파이프라인 안에서 호출)
+func publishChange(ctx context.Context, p redis.Pipeliner, diff *SyscallDiff) {
+	payload := diff.JSON()
+	p.Publish(ctx, ChangeChannel, payload)
+	p.XAdd(ctx, &redis.XAddArgs{
+		Stream: ChangeStreamKey,
+		MaxLen: changeStreamMaxLen,
+		Approx: true,
+		Values: map[string]interface{}{
+			"change":  diff.Change,
+			"sha256":  diff.SHA256,
+			"path":    diff.Path,
+			"added":   strings.Join(diff.Added, ","),
+			"removed": strings.Join(diff.Removed, ","),
+			"payload": payload,
+		},
+	})
+}
+
+// RecomputeClusterSet : cluster_callable_syscalls 를 인덱스의 모든 바이너리 syscalls Set 합집합으로 다시 계산
+// 인덱스를 WATCH 한 상태로 읽으므로, 도중에 인덱스가 바뀌면 트랜잭션이 실패하고 재시도됨
+func (s *RedisStore) RecomputeClusterSet(ctx context.Context) error {
+	err := s.retry.Do(ctx, "클러스터 Set 재계산", func() error {
+		return s.rdb.Watch(ctx, func(tx *redis.Tx) error {
+			ids, err := tx.SMembers(ctx, BinaryIndexKey).Result()
+			if err != nil {
+				return err
+			}
+			cmds, err := tx.TxPipelined(ctx, func(p redis.Pipeliner) error {
+				recomputeClusterScript.Eval(ctx, p, clusterKeys(ids), recomputeChunk)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			return execErr(cmds)
+		}, BinaryIndexKey)
+	})
+	if err != nil {
+		return fmt.Errorf("클러스터 Set 재계산 실패: %w", err)
+	}
+	return nil
+}
+
+// Load : 바이너리의 meta/wrappers/confidence/syscalls/bitmap 키를 읽어 분석 결과 구성
+func (s *RedisStore) Load(ctx context.Context, sha256 string) (*Analysis, error) {
+	pipe := s.rdb.Pipeline()
+	metaCmd := pipe.HGetAll(ctx, BinaryKey(sha256, "meta"))
+	wrappersCmd := pipe.HGetAll(ctx, BinaryKey(sha256, "wrappers"))
+	confidenceCmd := pipe.HGetAll(ctx, BinaryKey(sha256, "confidence"))
+	syscallsCmd := pipe.SMembers(ctx, BinaryKey(sha256, "syscalls"))
+	bitmapCmd := pipe.Get(ctx, SyscallBitmapKey(sha256, "x86_64"))
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, fmt.Errorf("Redis 조회 실패: %w", err)
+	}
+
+	meta := metaCmd.Val()
+	if len(meta) == 0 {
+		return nil, ErrNotFound
+	}
+	analyzedAt, _ := time.Parse(time.RFC3339, meta["analyzed_at"])
+
+	a := &Analysis{
+		Meta: Meta{
+			SHA256:          sha256,
+			Path:            meta["path"],
+			BuildID:         meta["build_id"],
+			LibcPath:        meta["libc_path"],
+			LibcBuildID:     meta["libc_build_id"],
+			LibcSHA256:      meta["libc_sha256"],
+			AnalyzerVersion: meta["analyzer_version"],
+			MinConfidence:   meta["min_confidence"],
+			DropDeadImports: meta["drop_dead_imports"] == "true",
+			DroppedImports:  splitList(meta["dropped_imports"]),
+			AnalyzedAt:      analyzedAt,
+		},
+		Wrappers:   wrappersCmd.Val(),
+		Confidence: confidenceCmd.Val(),
+		Syscalls:   syscallsCmd.Val(),
+	}
+	a.Bitmap, _ = bitmapCmd.Bytes()
+	sort.Strings(a.Syscalls)
+	return a, nil
+}
+
+// splitList : 쉼표로 이은 meta 값을 목록으로 (빈 값이면 nil)
+func splitList(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+// List : 분석 결과가 저장된 바이너리 sha256 목록
+func (s *RedisStore) List(ctx context.Context) ([]string, error) {
+	ids, err := s.rdb.SMembers(ctx, BinaryIndexKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("Redis 조회 실패: %w", err)
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// Delete : 바이너리의 모든 키와 인덱스 항목을 지우고 클러스터 Set을 다시 계산 (하나의 MULTI 트랜잭션)
+// 삭제된 시스템 콜 목록은 ChangeDeleted 이벤트로 발행
+func (s *RedisStore) Delete(ctx context.Context, sha256 string) error {
+	keys := binaryKeys(sha256)
+	liveSyscalls, liveMeta := BinaryKey(sha256, "syscalls"), BinaryKey(sha256, "meta")
+
+	found := false
+	err := s.rdb.Watch(ctx, func(tx *redis.Tx) error {
+		prev, err := tx.SMembers(ctx, liveSyscalls).Result()
+		if err != nil {
+			return err
+		}
+		path, err := tx.HGet(ctx, liveMeta, "path").Result()
+		if err != nil && !errors.Is(err, redis.Nil) {
+			return err
+		}
+		ids, err := tx.SMembers(ctx, BinaryIndexKey).Result()
+		if err != nil {
+			return err
+		}
+		exists, err := tx.Exists(ctx, keys...).Result()
+		if err != nil {
+			return err
+		}
+		var remaining []string
+		for _, id := range ids {
+			if id != sha256 {
+				remaining = append(remaining, id)
+			}
+		}
+		found = len(remaining) != len(ids) || exists > 0
+		if !found {
+			return nil
+		}
+		pointed := false // 경로가 이 결과를 가리키면 경로 키도 지움 (다음 저장이 지워진 결과와 비교하지 않도록)
+		if path != "" {
+			if err := tx.Watch(ctx, PathKey(path)).Err(); err != nil {
+				return err
+			}
+			last, err := tx.Get(ctx, PathKey(path)).Result()
+			if err != nil && !errors.Is(err, redis.Nil) {
+				return err
+			}
+			pointed = last == sha256
+		}
+
+		sort.Strings(prev)
+		diff := &SyscallDiff{
+			Change:    ChangeDeleted,
+			SHA256:    sha256,
+			Path:      path,
+			Added:     []string{},
+			Removed:   prev,
+			Timestamp: time.Now().UTC(),
+		}
+		cmds, err := tx.TxPipelined(ctx, func(p redis.Pipeliner) error {
+			p.Del(ctx, keys...)
+			p.SRem(ctx, BinaryIndexKey, sha256)
+			if pointed {
+				p.Del(ctx, PathKey(path))
+			}
+			recomputeClusterScript.Eval(ctx, p, clusterKeys(remaining), recomputeChunk)
+			publishChange(ctx, p, diff)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		return execErr(cmds)
+	}, liveSyscalls, liveMeta, BinaryIndexKey)
+	if err != nil {
+		return fmt.Errorf("Redis 삭제 실패: %w", err)
+	}
+	if !found {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Close : Redis 연결 종료
+func (s *RedisStore) Close() error {
+	return s.rdb.Close()
+}
@@ -0,0 +1,55 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+// RetryPolicy는 일시적인 저장소 오류(연결 실패, 타임아웃 등)에 대한 재시도 정책
+type RetryPolicy struct {
+	Attempts       int           // 최대 시도 횟수 (1 이하이면 한 번만 시도)
+	InitialBackoff time.Duration // 첫 재시도 전 대기 시간, 이후 두 배씩 증가
+	MaxBackoff     time.Duration // 대기 시간 상한
+}
+
+// DefaultRetryPolicy : 클러스터에서 Redis 파드가 잠시 재시작되는 정도는 버틸 수 있는 기본값 (최대 약 15초)
+var DefaultRetryPolicy = RetryPolicy{
+	Attempts:       5,
+	InitialBackoff: 500 * time.Millisecond,
+	MaxBackoff:     8 * time.Second,
+}
+
+// Do : fn이 성공하거나 시도 횟수를 모두 쓸 때까지 지수 백오프로 재시도
+// ctx가 취소되면 즉시 마지막 오류와 함께 반환
+func (p RetryPolicy) Do(ctx context.Context, op string, fn func() error) error {
+	attempts := p.Attempts
+	if attempts < 1 {
+		attempts = 1
+	}
+	backoff := p.InitialBackoff
+
+	var err error
+	for i := 1; i <= attempts; i++ {
+		if err = fn(); err == nil {
+			return nil
+		}
+		if i == attempts {
+			break
+		}
+
+		log.Printf("  [재시도] %s 실패 (%d/%d): %v, %v 후 재시도\n", op, i, attempts, err, backoff)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%s 중단: %w (마지막 오류: %v)", op, ctx.Err(), err)
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if p.MaxBackoff > 0 && backoff > p.MaxBackoff {
+			backoff = p.MaxBackoff
+		}
+	}
+	return fmt.Errorf("%s 실패 (%d회 시도): %w", op, attempts, err)
+}
@@ -0,0 +1,128 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"ips_bpf/static-analyzer/pkg/export"
+	"time"
+)
+
+// ErrNotFound는 요청한 바이너리의 분석 결과가 저장소에 없을 때 반환됩니다.
+var ErrNotFound = errors.New("저장된 분석 결과가 없습니다")
+
+// Meta는 분석 결과의 메타데이터
+type Meta struct {
+	SHA256          string    `json:"sha256"`                  // 분석 대상 파일의 sha256 (저장 키)
+	Path            string    `json:"path"`                    // 분석 대상 경로
+	BuildID         string    `json:"build_id,omitempty"`      // 분석 대상의 NT_GNU_BUILD_ID
+	LibcPath        string    `json:"libc_path"`               // 추적에 사용한 libc 경로
+	LibcBuildID     string    `json:"libc_build_id,omitempty"` // 추적에 사용한 libc의 NT_GNU_BUILD_ID
+	LibcSHA256      string    `json:"libc_sha256"`             // libc에 build-id가 없을 때 비교용
+	AnalyzerVersion string    `json:"analyzer_version"`
+	MinConfidence   string    `json:"min_confidence,omitempty"`    // 허용 목록에 넣은 최소 신뢰도 (--min-confidence)
+	DropDeadImports bool      `json:"drop_dead_imports,omitempty"` // 도달 불가능한 가져오기를 허용 목록에서 뺌 (--drop-dead-imports)
+	DroppedImports  []string  `json:"dropped_imports,omitempty"`   // 위 옵션으로 허용 목록에서 빠진 래퍼 (검토용, 캐시 조건 아님)
+	AnalyzedAt      time.Time `json:"analyzed_at"`                 // 분석 시각 (UTC)
+}
+
+// SameInputs : 두 메타데이터가 같은 입력(대상 파일, libc, 분석기 버전, 최소 신뢰도, dead import 제외 여부)으로 만든 결과인지 확인 (캐시 적중 조건)
+// 대상은 sha256으로, libc는 build-id가 양쪽에 있으면 build-id로, 아니면 sha256으로 비교
+func (m Meta) SameInputs(other Meta) (bool, string) {
+	switch {
+	case m.SHA256 != other.SHA256:
+		return false, "대상 파일 sha256 불일치"
+	case m.AnalyzerVersion != other.AnalyzerVersion:
+		return false, fmt.Sprintf("분석기 버전 불일치 (%s != %s)", m.AnalyzerVersion, other.AnalyzerVersion)
+	case m.MinConfidence != other.MinConfidence:
+		return false, fmt.Sprintf("최소 신뢰도 불일치 (%s != %s)", m.MinConfidence, other.MinConfidence)
+	case m.DropDeadImports != other.DropDeadImports:
+		return false, fmt.Sprintf("dead import 제외 여부 불일치 (%t != %t)", m.DropDeadImports, other.DropDeadImports)
+	case m.LibcBuildID != "" && other.LibcBuildID != "":
+		if m.LibcBuildID != other.LibcBuildID {
+			return false, fmt.Sprintf("libc build-id 불일치 (%s != %s)", m.LibcBuildID, other.LibcBuildID)
+		}
+	case m.LibcSHA256 != other.LibcSHA256:
+		return false, "libc sha256 불일치"
+	}
+	return true, ""
+}
+
+// Analysis는 바이너리 하나의 분석 결과 (저장 단위)
+type Analysis struct {
+	Meta
+	Wrappers   map[string]string `json:"wrappers"`             // {wrapper: kernelSyscall}
+	Confidence map[string]string `json:"confidence,omitempty"` // {wrapper: 신뢰도} (asmanalysis.Confidence)
+	Syscalls   []string          `json:"syscalls"`             // 허용 목록 (정렬, 중복 제거)
+	Bitmap     []byte            `json:"bitmap"`               // x86_64 허용 비트맵 64바이트 원본
+}
+
+// NewAnalysis : 메타데이터와 {wrapper: kernelSyscall} 맵으로 저장용 분석 결과 생성
+// meta.AnalyzedAt 이 비어 있으면 현재 시각으로 채움
+func NewAnalysis(meta Meta, wrappers map[string]string) *Analysis {
+	if meta.AnalyzedAt.IsZero() {
+		meta.AnalyzedAt = time.Now().UTC()
+	}
+	// 저장소는 x86_64 대상만 받음 (분석기가 다른 아키텍처 대상을 시작 전에 거부)
+	bitmap, _, _ := export.BuildSyscallBitmap(export.BitmapArch, wrappers)
+	return &Analysis{
+		Meta:     meta,
+		Wrappers: wrappers,
+		Syscalls: export.AllowList(wrappers),
+		Bitmap:   bitmap.Bitmap[:],
+	}
+}
+
+// ResultStore는 분석 결과 저장소 인터페이스
+// Redis 외에도 로컬 디렉터리 등 여러 백엔드로 구현할 수 있음
+type ResultStore interface {
+	// Save : 분석 결과 저장 (같은 sha256의 기존 결과는 덮어씀), 이전 결과와의 시스템 콜 차이를 반환
+	Save(ctx context.Context, a *Analysis) (*SyscallDiff, error)
+	// Load : 분석 대상 sha256으로 분석 결과 조회, 없으면 ErrNotFound
+	Load(ctx context.Context, sha256 string) (*Analysis, error)
+	// List : 저장된 분석 대상 sha256 목록
+	List(ctx context.Context) ([]string, error)
+	// Delete : 바이너리 하나의 분석 결과 삭제, 없으면 ErrNotFound
+	Delete(ctx context.Context, sha256 string) error
+	// LoadLibcTable : libc 심볼별 시스템 콜 표 조회 (key는 LibcTableKey), 없으면 ErrNotFound
+	LoadLibcTable(ctx context.Context, key string) (*LibcTable, error)
+	// SaveLibcTable : libc 심볼별 시스템 콜 표 저장 (같은 키의 기존 표는 덮어씀)
+	SaveLibcTable(ctx context.Context, t *LibcTable) error
+	// Close : 저장소 연결 정리
+	Close() error
+}
+
+// 저장소 종류
+const (
+	KindRedis = "redis"
+	KindFile  = "file"
+	KindNone  = "none" // 저장하지 않음 (로컬 분석 전용)
+)
+
+// Options는 Open에 넘기는 저장소 설정
+type Options struct {
+	Kind          string // KindRedis 또는 KindFile
+	RedisAddr     string
+	RedisPassword string
+	Dir           string      // KindFile 에서 사용할 디렉터리
+	Retry         RetryPolicy // 연결/쓰기 재시도 정책 (0값이면 DefaultRetryPolicy)
+}
+
+// Open : 설정에 맞는 ResultStore 생성
+// 연결 실패는 재시도 후 오류로 반환되므로 호출자가 분석 결과를 잃지 않고 처리할 수 있음
+func Open(ctx context.Context, opts Options) (ResultStore, error) {
+	if opts.Retry == (RetryPolicy{}) {
+		opts.Retry = DefaultRetryPolicy // 정책을 지정하지 않았을 때만 (Attempts 1 은 재시도 없음)
+	}
+
+	switch opts.Kind {
+	case KindRedis:
+		return NewRedisStore(ctx, opts.RedisAddr, opts.RedisPassword, opts.Retry)
+	case KindFile:
+		return NewFileStore(opts.Dir)
+	case KindNone:
+		return nopStore{}, nil
+	default:
+		return nil, fmt.Errorf("지원하지 않는 저장소 종류: %s", opts.Kind)
+	}
+}
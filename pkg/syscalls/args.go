@@ -0,0 +1,168 @@
+package syscalls
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ArgSpec은 래퍼 인자 하나와 그것이 전달되는 커널 시스템 콜 인자
+type ArgSpec struct {
+	Name       string             // 인자 이름 (예: domain, flags)
+	Reg        int                // 래퍼 호출 시 인자 순서 (0=rdi, 1=rsi, 2=rdx, 3=rcx)
+	SyscallArg int                // 커널 시스템 콜 인자 순서 (seccomp_data.args 인덱스)
+	Decode     func(int64) string // 상수 값을 이름으로 (모르면 "")
+}
+
+// GetArgSpecs는 호출 지점에서 상수 값을 복원할 래퍼 인자 목록을 반환합니다.
+// 목록에 없는 래퍼는 인자를 복원하지 않습니다.
+func GetArgSpecs(wrapper string) ([]ArgSpec, bool) {
+	specs, ok := argSpecMap[wrapper]
+	return specs, ok
+}
+
+// ArgSpecWrappers : 인자 복원 대상 래퍼 이름 목록 (정렬됨)
+func ArgSpecWrappers() []string {
+	names := make([]string, 0, len(argSpecMap))
+	for name := range argSpecMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+var argSpecMap = map[string][]ArgSpec{
+	// socket(domain, type, protocol)
+	"socket": {
+		{Name: "domain", Reg: 0, SyscallArg: 0, Decode: decodeEnum(addressFamilies)},
+		{Name: "type", Reg: 1, SyscallArg: 1, Decode: decodeSocketType},
+		{Name: "protocol", Reg: 2, SyscallArg: 2},
+	},
+	// clone(fn, stack, flags, arg, ...) - 커널 clone 의 첫 번째 인자가 flags
+	"clone": {
+		{Name: "flags", Reg: 2, SyscallArg: 0, Decode: decodeCloneFlags},
+	},
+	// prctl(option, ...)
+	"prctl": {
+		{Name: "option", Reg: 0, SyscallArg: 0, Decode: decodeEnum(prctlOptions)},
+	},
+	// ioctl(fd, request, ...)
+	"ioctl": {
+		{Name: "request", Reg: 1, SyscallArg: 1, Decode: decodeEnum(ioctlRequests)},
+	},
+	// mmap(addr, length, prot, flags, fd, offset)
+	"mmap": {
+		{Name: "prot", Reg: 2, SyscallArg: 2, Decode: decodeFlags(protFlags, "PROT_NONE")},
+	},
+	"mmap64": {
+		{Name: "prot", Reg: 2, SyscallArg: 2, Decode: decodeFlags(protFlags, "PROT_NONE")},
+	},
+	// mprotect(addr, len, prot)
+	"mprotect": {
+		{Name: "prot", Reg: 2, SyscallArg: 2, Decode: decodeFlags(protFlags, "PROT_NONE")},
+	},
+}
+
+// flagName은 비트 플래그 하나의 값과 이름
+type flagName struct {
+	value int64
+	name  string
+}
+
+// decodeEnum : 열거형 상수 이름
+func decodeEnum(names map[int64]string) func(int64) string {
+	return func(v int64) string {
+		return names[v]
+	}
+}
+
+// decodeFlags : 비트 플래그를 "A|B" 로 (알 수 없는 비트는 16진수로 덧붙임)
+func decodeFlags(flags []flagName, zero string) func(int64) string {
+	return func(v int64) string {
+		if v == 0 {
+			return zero
+		}
+		var parts []string
+		rest := v
+		for _, f := range flags {
+			if rest&f.value == f.value {
+				parts = append(parts, f.name)
+				rest &^= f.value
+			}
+		}
+		if rest != 0 {
+			parts = append(parts, fmt.Sprintf("0x%x", rest))
+		}
+		return strings.Join(parts, "|")
+	}
+}
+
+// decodeSocketType : SOCK_STREAM|SOCK_CLOEXEC 처럼 형식과 플래그를 함께 표시
+func decodeSocketType(v int64) string {
+	name, ok := socketTypes[v&0xf]
+	if !ok {
+		return ""
+	}
+	if flags := decodeFlags(socketTypeFlags, "")(v &^ 0xf); flags != "" {
+		name += "|" + flags
+	}
+	return name
+}
+
+// decodeCloneFlags : CLONE_* 플래그와 하위 바이트의 종료 시그널
+func decodeCloneFlags(v int64) string {
+	name := decodeFlags(cloneFlags, "")(v &^ 0xff)
+	if sig := v & 0xff; sig != 0 {
+		signal := fmt.Sprintf("signal %d", sig)
+		if sig == 17 {
+			signal = "SIGCHLD"
+		}
+		if name == "" {
+			return signal
+		}
+		name += "|" + signal
+	}
+	return name
+}
+
+var addressFamilies = map[int64]string{
+	0: "AF_UNSPEC", 1: "AF_UNIX", 2: "AF_INET", 10: "AF_INET6", 16: "AF_NETLINK", 17: "AF_PACKET",
+	4: "AF_IPX", 5: "AF_APPLETALK", 9: "AF_X25", 15: "AF_KEY", 31: "AF_BLUETOOTH", 38: "AF_ALG",
+	40: "AF_VSOCK", 44: "AF_XDP",
+}
+
+var socketTypes = map[int64]string{
+	1: "SOCK_STREAM", 2: "SOCK_DGRAM", 3: "SOCK_RAW", 4: "SOCK_RDM", 5: "SOCK_SEQPACKET", 10: "SOCK_PACKET",
+}
+
+var socketTypeFlags = []flagName{
+	{0x800, "SOCK_NONBLOCK"}, {0x80000, "SOCK_CLOEXEC"},
+}
+
+var cloneFlags = []flagName{
+	{0x100, "CLONE_VM"}, {0x200, "CLONE_FS"}, {0x400, "CLONE_FILES"}, {0x800, "CLONE_SIGHAND"},
+	{0x1000, "CLONE_PIDFD"}, {0x2000, "CLONE_PTRACE"}, {0x4000, "CLONE_VFORK"}, {0x8000, "CLONE_PARENT"},
+	{0x10000, "CLONE_THREAD"}, {0x20000, "CLONE_NEWNS"}, {0x40000, "CLONE_SYSVSEM"}, {0x80000, "CLONE_SETTLS"},
+	{0x100000, "CLONE_PARENT_SETTID"}, {0x200000, "CLONE_CHILD_CLEARTID"}, {0x800000, "CLONE_UNTRACED"},
+	{0x1000000, "CLONE_CHILD_SETTID"}, {0x2000000, "CLONE_NEWCGROUP"}, {0x4000000, "CLONE_NEWUTS"},
+	{0x8000000, "CLONE_NEWIPC"}, {0x10000000, "CLONE_NEWUSER"}, {0x20000000, "CLONE_NEWPID"},
+	{0x40000000, "CLONE_NEWNET"}, {0x80000000, "CLONE_IO"},
+}
+
+var prctlOptions = map[int64]string{
+	1: "PR_SET_PDEATHSIG", 2: "PR_GET_PDEATHSIG", 3: "PR_GET_DUMPABLE", 4: "PR_SET_DUMPABLE",
+	8: "PR_SET_KEEPCAPS", 15: "PR_SET_NAME", 16: "PR_GET_NAME", 22: "PR_SET_SECCOMP", 23: "PR_CAPBSET_READ",
+	24: "PR_CAPBSET_DROP", 36: "PR_SET_CHILD_SUBREAPER", 38: "PR_SET_NO_NEW_PRIVS", 39: "PR_GET_NO_NEW_PRIVS",
+	47: "PR_CAP_AMBIENT", 59: "PR_SET_VMA",
+}
+
+var ioctlRequests = map[int64]string{
+	0x5401: "TCGETS", 0x5402: "TCSETS", 0x5412: "TIOCSTI", 0x5413: "TIOCGWINSZ", 0x5414: "TIOCSWINSZ",
+	0x541b: "FIONREAD", 0x5421: "FIONBIO", 0x5451: "FIOCLEX", 0x5450: "FIONCLEX",
+	0x8912: "SIOCGIFCONF", 0x8913: "SIOCGIFFLAGS", 0x8914: "SIOCSIFFLAGS", 0x8915: "SIOCGIFADDR",
+	0x8927: "SIOCGIFHWADDR", 0x8933: "SIOCGIFINDEX",
+}
+
+var protFlags = []flagName{
+	{0x1, "PROT_READ"}, {0x2, "PROT_WRITE"}, {0x4, "PROT_EXEC"},
+}
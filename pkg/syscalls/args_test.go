@@ -0,0 +1,97 @@
+package syscalls
+
+import (
+	"sort"
+	"testing"
+)
+
+// argDecoder : 래퍼 인자의 Decode 함수 (테스트가 실제 argSpecMap 항목을 쓰도록)
+func argDecoder(t *testing.T, wrapper, name string) func(int64) string {
+	t.Helper()
+	specs, ok := GetArgSpecs(wrapper)
+	if !ok {
+		t.Fatalf("GetArgSpecs(%q): not found", wrapper)
+	}
+	for _, spec := range specs {
+		if spec.Name == name {
+			if spec.Decode == nil {
+				t.Fatalf("%s.%s has no decoder", wrapper, name)
+			}
+			return spec.Decode
+		}
+	}
+	t.Fatalf("%s has no %q argument", wrapper, name)
+	return nil
+}
+
+func TestDecodeArgs(t *testing.T) {
+	tests := []struct {
+		wrapper, arg string
+		value        int64
+		want         string
+	}{
+		// socket domain
+		{"socket", "domain", 2, "AF_INET"},
+		{"socket", "domain", 10, "AF_INET6"},
+		{"socket", "domain", 16, "AF_NETLINK"},
+		{"socket", "domain", 999, ""}, // 모르는 주소 체계
+
+		// socket type (하위 4비트 형식 + SOCK_NONBLOCK/SOCK_CLOEXEC)
+		{"socket", "type", 1, "SOCK_STREAM"},
+		{"socket", "type", 2 | 0x80000, "SOCK_DGRAM|SOCK_CLOEXEC"},
+		{"socket", "type", 1 | 0x800 | 0x80000, "SOCK_STREAM|SOCK_NONBLOCK|SOCK_CLOEXEC"},
+		{"socket", "type", 3 | 0x40000, "SOCK_RAW|0x40000"}, // 모르는 플래그 비트
+		{"socket", "type", 7, ""},                           // 모르는 형식
+		{"socket", "type", 0x80000, ""},                     // 형식 없이 플래그만
+
+		// clone flags (하위 바이트는 종료 시그널)
+		{"clone", "flags", 17, "SIGCHLD"},
+		{"clone", "flags", 0x100 | 0x200 | 0x400 | 0x800 | 0x10000, "CLONE_VM|CLONE_FS|CLONE_FILES|CLONE_SIGHAND|CLONE_THREAD"},
+		{"clone", "flags", 0x20000000 | 0x40000000 | 17, "CLONE_NEWPID|CLONE_NEWNET|SIGCHLD"},
+		{"clone", "flags", 0x100 | 9, "CLONE_VM|signal 9"},
+		{"clone", "flags", 0x400000, "0x400000"}, // 이름 표에 없는 비트 (CLONE_DETACHED)
+		{"clone", "flags", 0, ""},
+
+		// mmap/mprotect prot
+		{"mmap", "prot", 0, "PROT_NONE"},
+		{"mmap", "prot", 0x1 | 0x2, "PROT_READ|PROT_WRITE"},
+		{"mmap64", "prot", 0x1 | 0x4, "PROT_READ|PROT_EXEC"},
+		{"mprotect", "prot", 0x1 | 0x2 | 0x4, "PROT_READ|PROT_WRITE|PROT_EXEC"},
+		{"mprotect", "prot", 0x1 | 0x8, "PROT_READ|0x8"}, // 모르는 비트 (PROT_SEM)
+
+		// 열거형
+		{"prctl", "option", 38, "PR_SET_NO_NEW_PRIVS"},
+		{"prctl", "option", 1000, ""},
+		{"ioctl", "request", 0x5412, "TIOCSTI"},
+		{"ioctl", "request", 0x1234, ""},
+	}
+	for _, tt := range tests {
+		if got := argDecoder(t, tt.wrapper, tt.arg)(tt.value); got != tt.want {
+			t.Errorf("%s.%s(%#x) = %q, want %q", tt.wrapper, tt.arg, tt.value, got, tt.want)
+		}
+	}
+}
+
+// TestArgSpecs : 인자 순서가 레지스터 인자 범위 안이고, ArgSpecWrappers 가 정렬된 전체 목록인지 확인
+func TestArgSpecs(t *testing.T) {
+	wrappers := ArgSpecWrappers()
+	if !sort.StringsAreSorted(wrappers) || len(wrappers) != len(argSpecMap) {
+		t.Errorf("ArgSpecWrappers() = %v", wrappers)
+	}
+	if _, ok := GetArgSpecs("read"); ok {
+		t.Errorf("GetArgSpecs(read) = ok, want not found")
+	}
+	for _, wrapper := range wrappers {
+		specs, _ := GetArgSpecs(wrapper)
+		seen := make(map[string]bool)
+		for _, spec := range specs {
+			if spec.Reg < 0 || spec.Reg > 5 || spec.SyscallArg < 0 || spec.SyscallArg > 5 {
+				t.Errorf("%s.%s: Reg %d, SyscallArg %d out of range", wrapper, spec.Name, spec.Reg, spec.SyscallArg)
+			}
+			if seen[spec.Name] {
+				t.Errorf("%s: duplicate argument %q", wrapper, spec.Name)
+			}
+			seen[spec.Name] = true
+		}
+	}
+}
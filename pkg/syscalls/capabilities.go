@@ -0,0 +1,213 @@
+package syscalls
+
+import "sort"
+
+// RiskCategory는 시스템 콜이 속한 위험 범주
+type RiskCategory string
+
+const (
+	RiskPrivilege     RiskCategory = "privilege"      // 자격 증명(uid/gid/capability) 변경
+	RiskMount         RiskCategory = "mount"          // 파일 시스템 마운트/루트 변경
+	RiskNamespace     RiskCategory = "namespace"      // 네임스페이스 생성/진입 (컨테이너 탈출 경로)
+	RiskKernelModule  RiskCategory = "kernel-module"  // 커널 모듈 적재/제거
+	RiskTracing       RiskCategory = "tracing"        // 다른 프로세스 메모리/실행 관찰 및 조작
+	RiskBPF           RiskCategory = "bpf"            // BPF 프로그램/맵 조작
+	RiskSystem        RiskCategory = "system"         // 재부팅, 시간, 호스트 이름 등 시스템 전역 설정
+	RiskHardware      RiskCategory = "hardware"       // I/O 포트 직접 접근
+	RiskFilesystem    RiskCategory = "filesystem"     // 파일 소유권/장치 파일/핸들 기반 접근
+	RiskResource      RiskCategory = "resource"       // 스케줄링, 메모리 잠금, 자원 한도
+	RiskAttackSurface RiskCategory = "attack-surface" // 권한은 필요 없지만 커널 공격에 자주 쓰이는 인터페이스
+)
+
+// SyscallRisk는 시스템 콜 하나가 암시하는 Linux capability와 위험 정보
+type SyscallRisk struct {
+	Capabilities []string     // 필요할 수 있는 capability (CAP_ 접두사 포함)
+	Category     RiskCategory // 위험 범주
+	Dangerous    bool         // 컨테이너에서 허용 시 특별히 검토가 필요한 시스템 콜인지
+	Conditional  bool         // 인자에 따라 특권이 필요한 경우에만 capability 가 필요함 (예: 자기 파일 chown, 한도를 낮추는 prlimit64)
+}
+
+// GetSyscallRisk는 커널 시스템 콜 이름에 대한 capability/위험 정보를 반환합니다.
+// 인자에 따라 capability가 필요 없는 경우도 있으므로 (예: setuid로 자기 uid 유지) "필요할 수 있음"으로 해석해야 합니다.
+func GetSyscallRisk(kernelSyscallName string) (SyscallRisk, bool) {
+	risk, ok := syscallRiskMap[kernelSyscallName]
+	return risk, ok
+}
+
+// CapabilityUsage는 capability 하나와 그것을 요구하는 시스템 콜 목록
+type CapabilityUsage struct {
+	Capability string   `json:"capability"`
+	Syscalls   []string `json:"syscalls"`
+}
+
+// DangerousSyscall은 보고서에 표시할 위험 시스템 콜
+type DangerousSyscall struct {
+	Name         string       `json:"name"`
+	Category     RiskCategory `json:"category"`
+	Capabilities []string     `json:"capabilities,omitempty"`
+	Conditional  bool         `json:"conditional,omitempty"` // capability 는 특권이 필요한 인자일 때만 필요
+}
+
+// CapabilityReport는 시스템 콜 목록으로부터 만든 capability 요약
+// Capabilities 는 항상 capability 가 필요한 시스템 콜(mount, init_module, ptrace 등)이 하나라도 있는 capability,
+// MayRequire 는 조건부(Conditional) 시스템 콜만 요구하는 capability (securityContext 제안에는 넣지 않음)
+type CapabilityReport struct {
+	Capabilities []CapabilityUsage  `json:"capabilities"`
+	MayRequire   []CapabilityUsage  `json:"may_require"`
+	Dangerous    []DangerousSyscall `json:"dangerous"`
+}
+
+// CapabilityNames : 보고서의 항상 필요한 capability 이름 목록 (정렬됨, MayRequire 제외)
+func (r CapabilityReport) CapabilityNames() []string {
+	names := make([]string, 0, len(r.Capabilities))
+	for _, c := range r.Capabilities {
+		names = append(names, c.Capability)
+	}
+	return names
+}
+
+// BuildCapabilityReport는 커널 시스템 콜 이름 목록으로 필요한 capability와 위험 시스템 콜을 정리합니다.
+// 조건부 시스템 콜만 요구하는 capability 는 MayRequire 로 분리합니다.
+func BuildCapabilityReport(kernelSyscallNames []string) CapabilityReport {
+	bycap := make(map[string][]string)
+	required := make(map[string]bool) // 조건 없이 요구하는 시스템 콜이 있는 capability
+	report := CapabilityReport{
+		Capabilities: []CapabilityUsage{},
+		MayRequire:   []CapabilityUsage{},
+		Dangerous:    []DangerousSyscall{},
+	}
+
+	names := append([]string(nil), kernelSyscallNames...)
+	sort.Strings(names)
+	for _, name := range names {
+		risk, ok := syscallRiskMap[name]
+		if !ok {
+			continue
+		}
+		for _, c := range risk.Capabilities {
+			bycap[c] = append(bycap[c], name)
+			if !risk.Conditional {
+				required[c] = true
+			}
+		}
+		if risk.Dangerous {
+			report.Dangerous = append(report.Dangerous, DangerousSyscall{
+				Name:         name,
+				Category:     risk.Category,
+				Capabilities: risk.Capabilities,
+				Conditional:  risk.Conditional,
+			})
+		}
+	}
+
+	for c, scs := range bycap {
+		if required[c] {
+			report.Capabilities = append(report.Capabilities, CapabilityUsage{Capability: c, Syscalls: scs})
+		} else {
+			report.MayRequire = append(report.MayRequire, CapabilityUsage{Capability: c, Syscalls: scs})
+		}
+	}
+	for _, usages := range [][]CapabilityUsage{report.Capabilities, report.MayRequire} {
+		sort.Slice(usages, func(i, j int) bool { return usages[i].Capability < usages[j].Capability })
+	}
+	return report
+}
+
+// syscallRiskMap은 capability 또는 위험 범주와 관련된 시스템 콜 표 (x86_64 커널 이름 기준)
+// 참고: capabilities(7), 각 시스템 콜 man 페이지의 EPERM 항목, Docker 기본 seccomp 프로파일
+// Conditional : 비특권 사용(자기 uid/파일, 한도 낮추기, FIFO 생성 등)에는 capability 가 필요 없는 시스템 콜
+var syscallRiskMap = map[string]SyscallRisk{
+	// 자격 증명 변경
+	"setuid":    {Capabilities: []string{"CAP_SETUID"}, Category: RiskPrivilege, Conditional: true},
+	"setreuid":  {Capabilities: []string{"CAP_SETUID"}, Category: RiskPrivilege, Conditional: true},
+	"setresuid": {Capabilities: []string{"CAP_SETUID"}, Category: RiskPrivilege, Conditional: true},
+	"setfsuid":  {Capabilities: []string{"CAP_SETUID"}, Category: RiskPrivilege, Conditional: true},
+	"setgid":    {Capabilities: []string{"CAP_SETGID"}, Category: RiskPrivilege, Conditional: true},
+	"setregid":  {Capabilities: []string{"CAP_SETGID"}, Category: RiskPrivilege, Conditional: true},
+	"setresgid": {Capabilities: []string{"CAP_SETGID"}, Category: RiskPrivilege, Conditional: true},
+	"setfsgid":  {Capabilities: []string{"CAP_SETGID"}, Category: RiskPrivilege, Conditional: true},
+	"setgroups": {Capabilities: []string{"CAP_SETGID"}, Category: RiskPrivilege},
+	"capset":    {Capabilities: []string{"CAP_SETPCAP"}, Category: RiskPrivilege, Dangerous: true, Conditional: true},
+
+	// 마운트 / 루트 변경
+	"mount":         {Capabilities: []string{"CAP_SYS_ADMIN"}, Category: RiskMount, Dangerous: true},
+	"umount2":       {Capabilities: []string{"CAP_SYS_ADMIN"}, Category: RiskMount, Dangerous: true},
+	"pivot_root":    {Capabilities: []string{"CAP_SYS_ADMIN"}, Category: RiskMount, Dangerous: true},
+	"move_mount":    {Capabilities: []string{"CAP_SYS_ADMIN"}, Category: RiskMount, Dangerous: true},
+	"open_tree":     {Capabilities: []string{"CAP_SYS_ADMIN"}, Category: RiskMount, Dangerous: true},
+	"fsopen":        {Capabilities: []string{"CAP_SYS_ADMIN"}, Category: RiskMount, Dangerous: true},
+	"fsconfig":      {Capabilities: []string{"CAP_SYS_ADMIN"}, Category: RiskMount, Dangerous: true},
+	"fsmount":       {Capabilities: []string{"CAP_SYS_ADMIN"}, Category: RiskMount, Dangerous: true},
+	"fspick":        {Capabilities: []string{"CAP_SYS_ADMIN"}, Category: RiskMount, Dangerous: true},
+	"mount_setattr": {Capabilities: []string{"CAP_SYS_ADMIN"}, Category: RiskMount, Dangerous: true},
+	"chroot":        {Capabilities: []string{"CAP_SYS_CHROOT"}, Category: RiskMount, Dangerous: true},
+	"swapon":        {Capabilities: []string{"CAP_SYS_ADMIN"}, Category: RiskSystem, Dangerous: true},
+	"swapoff":       {Capabilities: []string{"CAP_SYS_ADMIN"}, Category: RiskSystem, Dangerous: true},
+	"quotactl":      {Capabilities: []string{"CAP_SYS_ADMIN"}, Category: RiskFilesystem, Conditional: true},
+	"fanotify_init": {Capabilities: []string{"CAP_SYS_ADMIN"}, Category: RiskFilesystem, Dangerous: true},
+
+	// 네임스페이스
+	"unshare": {Capabilities: []string{"CAP_SYS_ADMIN"}, Category: RiskNamespace, Dangerous: true, Conditional: true},
+	"setns":   {Capabilities: []string{"CAP_SYS_ADMIN"}, Category: RiskNamespace, Dangerous: true},
+
+	// 커널 모듈
+	"init_module":   {Capabilities: []string{"CAP_SYS_MODULE"}, Category: RiskKernelModule, Dangerous: true},
+	"finit_module":  {Capabilities: []string{"CAP_SYS_MODULE"}, Category: RiskKernelModule, Dangerous: true},
+	"delete_module": {Capabilities: []string{"CAP_SYS_MODULE"}, Category: RiskKernelModule, Dangerous: true},
+
+	// 프로세스 추적
+	"ptrace":            {Capabilities: []string{"CAP_SYS_PTRACE"}, Category: RiskTracing, Dangerous: true},
+	"process_vm_readv":  {Capabilities: []string{"CAP_SYS_PTRACE"}, Category: RiskTracing, Dangerous: true},
+	"process_vm_writev": {Capabilities: []string{"CAP_SYS_PTRACE"}, Category: RiskTracing, Dangerous: true},
+	"kcmp":              {Capabilities: []string{"CAP_SYS_PTRACE"}, Category: RiskTracing, Conditional: true},
+	"perf_event_open":   {Capabilities: []string{"CAP_PERFMON"}, Category: RiskTracing, Dangerous: true, Conditional: true},
+
+	// BPF
+	"bpf": {Capabilities: []string{"CAP_BPF", "CAP_PERFMON"}, Category: RiskBPF, Dangerous: true, Conditional: true},
+
+	// 시스템 전역 설정
+	"reboot":          {Capabilities: []string{"CAP_SYS_BOOT"}, Category: RiskSystem, Dangerous: true},
+	"kexec_load":      {Capabilities: []string{"CAP_SYS_BOOT"}, Category: RiskSystem, Dangerous: true},
+	"kexec_file_load": {Capabilities: []string{"CAP_SYS_BOOT"}, Category: RiskSystem, Dangerous: true},
+	"sethostname":     {Capabilities: []string{"CAP_SYS_ADMIN"}, Category: RiskSystem},
+	"setdomainname":   {Capabilities: []string{"CAP_SYS_ADMIN"}, Category: RiskSystem},
+	"settimeofday":    {Capabilities: []string{"CAP_SYS_TIME"}, Category: RiskSystem, Dangerous: true},
+	"clock_settime":   {Capabilities: []string{"CAP_SYS_TIME"}, Category: RiskSystem, Dangerous: true},
+	"clock_adjtime":   {Capabilities: []string{"CAP_SYS_TIME"}, Category: RiskSystem, Dangerous: true},
+	"adjtimex":        {Capabilities: []string{"CAP_SYS_TIME"}, Category: RiskSystem},
+	"acct":            {Capabilities: []string{"CAP_SYS_PACCT"}, Category: RiskSystem, Dangerous: true},
+	"syslog":          {Capabilities: []string{"CAP_SYSLOG"}, Category: RiskSystem},
+	"vhangup":         {Capabilities: []string{"CAP_SYS_TTY_CONFIG"}, Category: RiskSystem},
+
+	// 하드웨어 직접 접근
+	"iopl":   {Capabilities: []string{"CAP_SYS_RAWIO"}, Category: RiskHardware, Dangerous: true},
+	"ioperm": {Capabilities: []string{"CAP_SYS_RAWIO"}, Category: RiskHardware, Dangerous: true},
+
+	// 파일 시스템
+	"chown":             {Capabilities: []string{"CAP_CHOWN"}, Category: RiskFilesystem, Conditional: true},
+	"fchown":            {Capabilities: []string{"CAP_CHOWN"}, Category: RiskFilesystem, Conditional: true},
+	"lchown":            {Capabilities: []string{"CAP_CHOWN"}, Category: RiskFilesystem, Conditional: true},
+	"fchownat":          {Capabilities: []string{"CAP_CHOWN"}, Category: RiskFilesystem, Conditional: true},
+	"mknod":             {Capabilities: []string{"CAP_MKNOD"}, Category: RiskFilesystem, Conditional: true},
+	"mknodat":           {Capabilities: []string{"CAP_MKNOD"}, Category: RiskFilesystem, Conditional: true},
+	"open_by_handle_at": {Capabilities: []string{"CAP_DAC_READ_SEARCH"}, Category: RiskFilesystem, Dangerous: true},
+
+	// 자원 / 스케줄링
+	"mlock":              {Capabilities: []string{"CAP_IPC_LOCK"}, Category: RiskResource, Conditional: true},
+	"mlock2":             {Capabilities: []string{"CAP_IPC_LOCK"}, Category: RiskResource, Conditional: true},
+	"mlockall":           {Capabilities: []string{"CAP_IPC_LOCK"}, Category: RiskResource, Conditional: true},
+	"setpriority":        {Capabilities: []string{"CAP_SYS_NICE"}, Category: RiskResource, Conditional: true},
+	"sched_setscheduler": {Capabilities: []string{"CAP_SYS_NICE"}, Category: RiskResource, Conditional: true},
+	"sched_setattr":      {Capabilities: []string{"CAP_SYS_NICE"}, Category: RiskResource, Conditional: true},
+	"setrlimit":          {Capabilities: []string{"CAP_SYS_RESOURCE"}, Category: RiskResource, Conditional: true},
+	"prlimit64":          {Capabilities: []string{"CAP_SYS_RESOURCE"}, Category: RiskResource, Conditional: true},
+
+	// 권한은 필요 없지만 검토가 필요한 인터페이스
+	"userfaultfd":       {Category: RiskAttackSurface, Dangerous: true},
+	"io_uring_setup":    {Category: RiskAttackSurface, Dangerous: true},
+	"io_uring_enter":    {Category: RiskAttackSurface, Dangerous: true},
+	"io_uring_register": {Category: RiskAttackSurface, Dangerous: true},
+	"keyctl":            {Category: RiskAttackSurface, Dangerous: true},
+	"add_key":           {Category: RiskAttackSurface, Dangerous: true},
+	"request_key":       {Category: RiskAttackSurface, Dangerous: true},
+}
@@ -1,11 +1,34 @@
 package syscalls
 
+import "sort"
+
 // GetKernelSyscallName은 커널 시스템 콜 번호를 이름으로 변환합니다.
 func GetKernelSyscallName(num int64) (string, bool) {
 	name, ok := kernelSyscallNameMap[num]
 	return name, ok
 }
 
+// GetKernelSyscallNumber는 커널 시스템 콜 이름을 번호로 변환합니다. (GetKernelSyscallName의 역방향)
+func GetKernelSyscallNumber(name string) (int64, bool) {
+	num, ok := kernelSyscallNumberMap[name]
+	return num, ok
+}
+
+// KernelSyscallNames는 커널 시스템 콜 이름 전체를 번호 순으로 반환합니다.
+func KernelSyscallNames() []string {
+	nums := make([]int64, 0, len(kernelSyscallNameMap))
+	for num := range kernelSyscallNameMap {
+		nums = append(nums, num)
+	}
+	sort.Slice(nums, func(i, j int) bool { return nums[i] < nums[j] })
+
+	names := make([]string, len(nums))
+	for i, num := range nums {
+		names[i] = kernelSyscallNameMap[num]
+	}
+	return names
+}
+
 // IsTracepointAvailable는 커널 시스템 콜 이름에 해당하는 'sys_enter' Tracepoint가
 // 사용 가능한지 확인합니다.
 func IsTracepointAvailable(kernelSyscallName string) bool {
@@ -15,6 +38,15 @@ func IsTracepointAvailable(kernelSyscallName string) bool {
 	return ok
 }
 
+// kernelSyscallNumberMap은 kernelSyscallNameMap의 역방향 맵 (이름 -> 번호)
+var kernelSyscallNumberMap = func() map[string]int64 {
+	m := make(map[string]int64, len(kernelSyscallNameMap))
+	for num, name := range kernelSyscallNameMap {
+		m[name] = num
+	}
+	return m
+}()
+
 var kernelSyscallNameMap = map[int64]string{
 	0:   "read",
 	1:   "write",